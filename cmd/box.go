@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/output"
+	"github.com/internetdrew/bball/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var boxCmd = &cobra.Command{
+	Use:   "box [team|gameId]",
+	Short: "Show the full box score for a game",
+	Long:  "Display both teams' box scores for today's game involving a team, or for a specific game ID (e.g. 0022300061).",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := newClient()
+
+		query, err := teamArg(args)
+		if err != nil {
+			return err
+		}
+
+		gameID, err := resolveGameID(cmd.Context(), client, query)
+		if err != nil {
+			return err
+		}
+		if gameID == "" {
+			if structuredOutput() {
+				return renderNoBoxscore(cmd, query, "")
+			}
+			fmt.Println("No game today for that team.")
+			return nil
+		}
+
+		box, err := client.FetchBoxscore(cmd.Context(), gameID)
+		if err != nil {
+			if !isNotAvailableYet(err) {
+				return err
+			}
+			if structuredOutput() {
+				return renderNoBoxscore(cmd, query, gameID)
+			}
+			fmt.Println("Box score isn't available yet. It appears shortly before tip-off.")
+			return nil
+		}
+
+		if structuredOutput() {
+			return render(cmd, box, output.BoxscoreTable(*box))
+		}
+
+		fmt.Print(util.FormatBoxscore(*box))
+		return nil
+	},
+}
+
+// noBoxscore is box's JSON and YAML when there's no box score to show: the
+// team without a game today, or the game whose box score isn't out yet, and
+// a null game where a box score would have it.
+type noBoxscore struct {
+	Team   string            `json:"team,omitempty"`
+	GameID string            `json:"game_id,omitempty"`
+	Game   *nba.BoxscoreGame `json:"game"`
+}
+
+// renderNoBoxscore renders a noBoxscore for query, and gameID when there is
+// one, with the box score table's header and no rows.
+func renderNoBoxscore(cmd *cobra.Command, query, gameID string) error {
+	none := noBoxscore{GameID: gameID}
+	if franchise, err := nba.Resolve(query); err == nil {
+		none.Team = franchise.Tricode
+	}
+	return render(cmd, none, output.Table{Header: output.BoxscoreTable(nba.Boxscore{}).Header})
+}
+
+// resolveGameID returns query itself if it is a game ID, otherwise the ID of
+// today's game for the team it names. It returns "" if the team has no game
+// today.
+func resolveGameID(ctx context.Context, client *nba.Client, query string) (string, error) {
+	query = strings.TrimSpace(strings.ToLower(query))
+	if query == "" {
+		return "", fmt.Errorf("please specify a team name or game ID")
+	}
+	if nba.IsGameID(query) {
+		return query, nil
+	}
+
+	game, err := client.FindTodaysTeamGame(ctx, query)
+	if err != nil {
+		if errors.Is(err, nba.ErrTeamNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return game.ID, nil
+}
+
+// isNotAvailableYet reports whether err is the CDN's answer for a liveData
+// file that has not been published yet.
+func isNotAvailableYet(err error) bool {
+	var httpErr *nba.HTTPError
+	return errors.As(err, &httpErr) &&
+		(httpErr.StatusCode == http.StatusNotFound || httpErr.StatusCode == http.StatusForbidden)
+}
+
+func init() {
+	rootCmd.AddCommand(boxCmd)
+}
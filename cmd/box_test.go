@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/output"
+)
+
+func TestNoBoxscore_IsAnObject(t *testing.T) {
+	var out bytes.Buffer
+	if err := output.Render(&out, output.JSON, noBoxscore{Team: "BOS"}, output.Table{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("expected an object, got %s", out.String())
+	}
+	if game, ok := got["game"]; got["team"] != "BOS" || !ok || game != nil {
+		t.Fatalf("unexpected output %s", out.String())
+	}
+}
+
+func TestNoBoxscore_GameNotOutYet(t *testing.T) {
+	var out bytes.Buffer
+	if err := output.Render(&out, output.JSON, noBoxscore{GameID: "0022400061"}, output.Table{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); !strings.Contains(got, `"game_id": "0022400061"`) || !strings.Contains(got, `"game": null`) || strings.Contains(got, `"team"`) {
+		t.Fatalf("unexpected output %s", got)
+	}
+}
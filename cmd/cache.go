@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/internetdrew/bball/internal/output"
+	"github.com/internetdrew/bball/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var pruneOlderThan time.Duration
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and manage the response cache",
+	Long:  "bball caches the league schedule and scoreboard on disk so repeated commands are fast.\n\nUse the subcommands to show, prune, or clear the cached responses.",
+}
+
+var cacheShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "List cached responses",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c := openCache()
+		if c == nil {
+			return fmt.Errorf("no cache directory available")
+		}
+
+		entries, err := c.List()
+		if err != nil {
+			return fmt.Errorf("failed to read cache: %w", err)
+		}
+
+		if structuredOutput() {
+			return render(cmd, entries, output.CacheTable(entries))
+		}
+
+		fmt.Print(util.FormatCacheEntries(c.Dir, entries))
+		return nil
+	},
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove cached responses older than --older-than",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c := openCache()
+		if c == nil {
+			return fmt.Errorf("no cache directory available")
+		}
+
+		removed, err := c.Prune(pruneOlderThan)
+		if err != nil {
+			return fmt.Errorf("failed to prune cache: %w", err)
+		}
+
+		return reportRemoved(cmd, removed)
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove every cached response",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c := openCache()
+		if c == nil {
+			return fmt.Errorf("no cache directory available")
+		}
+
+		removed, err := c.Clear()
+		if err != nil {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+
+		return reportRemoved(cmd, removed)
+	},
+}
+
+// reportRemoved prints how many cached responses prune or clear removed.
+func reportRemoved(cmd *cobra.Command, removed int) error {
+	if structuredOutput() {
+		return render(cmd, map[string]int{"removed": removed}, output.Table{
+			Header: []string{"removed"},
+			Rows:   [][]string{{strconv.Itoa(removed)}},
+		})
+	}
+
+	fmt.Printf("Removed %d cached response(s).\n", removed)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheShowCmd, cachePruneCmd, cacheClearCmd)
+	cachePruneCmd.Flags().DurationVar(&pruneOlderThan, "older-than", 24*time.Hour, "Remove responses fetched longer ago than this")
+}
@@ -1,62 +1,83 @@
 package cmd
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/output"
 	"github.com/internetdrew/bball/internal/util"
 	"github.com/spf13/cobra"
 )
 
+var catchWatch time.Duration
+
 var catchCmd = &cobra.Command{
 	Use:   "catch [team]",
-	Short: "Catch up on a current (live) game for a team",
-	Args:  cobra.ExactArgs(1),
+	Short: "Catch up on a team's game today",
+	Long: "Show a summary of a team's game today: a preview before tip-off (tip time, records, rest days,\n" +
+		"the last meeting and broadcasters), the score, linescore and leaders once it's under way, and the\n" +
+		"final with the same recap afterwards. On a day the team doesn't play, shows its last result and\n" +
+		"its next game.\n\n" +
+		"Use --watch to keep it updated until the game goes final.\n" +
+		"Without a team, uses the first favorite team from the config file.",
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) == 0 {
-			return fmt.Errorf("please specify a team name")
+		query, err := teamArg(args)
+		if err != nil {
+			return err
 		}
 
-		team := strings.TrimSpace(strings.ToLower(args[0]))
-		if team == "" {
-			return fmt.Errorf("please specify a team name")
+		franchise, err := resolveTeam(query)
+		if err != nil {
+			return err
 		}
 
-		game, err := nba.FindTeamGame(team)
+		client := newClient()
+
+		board, err := client.FetchScoreboard(cmd.Context())
 		if err != nil {
-			if errors.Is(err, nba.ErrTeamNotFound) {
-				fmt.Println("No current game found for that team.")
-				return nil
-			}
 			return err
 		}
+		var game *nba.Game
+		if g, ok := board.TeamGame(franchise); ok {
+			game = &g
+		}
+
+		// Previews and off days need the schedule; a game under way doesn't.
+		var schedule *nba.LeagueScheduleResponse
+		if game == nil || !game.State().HasScore() {
+			if schedule, err = fetchScheduleOrCached(cmd.Context(), client); err != nil {
+				return err
+			}
+		}
 
 		if game == nil {
-			fmt.Println("No current game found for that team.")
+			offDay := schedule.OffDay(franchise, time.Now())
+			if structuredOutput() {
+				return render(cmd, offDay, output.OffDayTable(offDay))
+			}
+			fmt.Print(util.FormatOffDay(offDay))
+			return nil
+		}
+
+		if catchWatch > 0 {
+			return watchGame(cmd.Context(), client, *game, franchise, schedule, catchWatch)
+		}
+
+		if !game.State().HasScore() {
+			preview := schedule.Preview(*game, franchise)
+			if structuredOutput() {
+				return render(cmd, preview, output.PreviewTable(preview))
+			}
+			fmt.Print(util.FormatPreview(preview))
 			return nil
 		}
 
-		summary := nba.GameSummary{
-			Game: *game,
-			TopPerformers: []nba.PlayerStats{
-				{
-					PlayerName: game.GameLeaders.HomeLeaders.Name,
-					TeamCode:   game.GameLeaders.HomeLeaders.TeamTricode,
-					Points:     game.GameLeaders.HomeLeaders.Points,
-					Rebounds:   game.GameLeaders.HomeLeaders.Rebounds,
-					Assists:    game.GameLeaders.HomeLeaders.Assists,
-				},
-				{
-					PlayerName: game.GameLeaders.AwayLeaders.Name,
-					TeamCode:   game.GameLeaders.AwayLeaders.TeamTricode,
-					Points:     game.GameLeaders.AwayLeaders.Points,
-					Rebounds:   game.GameLeaders.AwayLeaders.Rebounds,
-					Assists:    game.GameLeaders.AwayLeaders.Assists,
-				},
-			},
-			LastUpdated: "just now",
+		summary := buildSummary(*game, board)
+		if structuredOutput() {
+			return render(cmd, summary, output.SummaryTable(summary))
 		}
 
 		fmt.Print(util.FormatGameSummary(summary))
@@ -65,6 +86,63 @@ var catchCmd = &cobra.Command{
 	},
 }
 
+// buildSummary assembles the catch-up view of a game from its entry in
+// board. A scoreboard that doesn't say when it was written is taken as
+// fresh.
+func buildSummary(game nba.Game, board *nba.Scoreboard) nba.GameSummary {
+	now := time.Now()
+	updated, ok := board.UpdatedAt()
+	if !ok {
+		updated = now
+	}
+
+	summary := nba.NewGameSummary(game)
+	summary.SetUpdated(updated, now)
+	return summary
+}
+
+// watchGame redraws the summary for game every interval, highlighting what
+// changed, and stops once the game is final. Until tip-off it shows the
+// preview instead when schedule is set.
+func watchGame(ctx context.Context, client *nba.Client, game nba.Game, franchise *nba.Franchise, schedule *nba.LeagueScheduleResponse, interval time.Duration) error {
+	tracker := nba.NewGameTracker(game)
+
+	return runWatch(ctx, interval, func(ctx context.Context) (watchFrame, error) {
+		scoreboard, err := client.FetchScoreboard(ctx)
+		if err != nil {
+			return watchFrame{}, err
+		}
+
+		current, ok := findGameByID(scoreboard.Scoreboard.Games, game.ID)
+		if !ok {
+			// The scoreboard rolls over to the next day overnight.
+			return watchFrame{output: "The game is no longer on today's scoreboard.\n", done: true}, nil
+		}
+
+		var out string
+		if schedule != nil && !current.State().HasScore() {
+			out = util.FormatPreview(schedule.Preview(current, franchise))
+		} else {
+			out = util.FormatGameSummary(buildSummary(current, scoreboard))
+		}
+		if changes := tracker.Update(current); changes.Any() {
+			out += util.FormatChanges(current, changes) + "\n"
+		}
+
+		return watchFrame{output: out, live: current.State().IsLive(), done: current.State().IsDone()}, nil
+	})
+}
+
+func findGameByID(games []nba.Game, id string) (nba.Game, bool) {
+	for _, g := range games {
+		if g.ID == id {
+			return g, true
+		}
+	}
+	return nba.Game{}, false
+}
+
 func init() {
 	rootCmd.AddCommand(catchCmd)
+	addWatchFlag(catchCmd, &catchWatch)
 }
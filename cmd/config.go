@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/internetdrew/bball/internal/config"
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/output"
+	"github.com/spf13/cobra"
+)
+
+const configTemplate = `# bball configuration. Every setting is optional.
+#
+# favorite_teams: [knicks]      # used when a command is run without a team
+# timezone: local               # local, arena, or an IANA name like America/Chicago
+# output: text                  # text, json, yaml, csv, or tsv
+# theme: default                # default or mono (no colors)
+# schedule_window: 5            # games shown by "bball schedule"
+# cache:
+#   enabled: true
+#   dir: ~/.cache/bball
+#   schedule_ttl: 6h
+#   scoreboard_ttl: 10s
+# profiles:                     # select with --profile or BBALL_PROFILE
+#   west:
+#     favorite_teams: [lakers]
+#     timezone: America/Los_Angeles
+`
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and change bball settings",
+	Long: "Manage the bball config file.\n\nKeys: " + strings.Join(config.Keys, ", ") +
+		"\nPrefix a key with profiles.<name>. to change it only for that profile, e.g. profiles.west.timezone.",
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Print one setting, or all of them",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, _, err := loadConfigFile()
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			value, err := c.Get(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(value)
+			return nil
+		}
+
+		for _, key := range config.Keys {
+			value, _ := c.Get(key)
+			fmt.Printf("%s = %s\n", key, value)
+		}
+		for _, name := range c.ProfileNames() {
+			for _, key := range config.Keys {
+				if value, _ := c.Get("profiles." + name + "." + key); value != "" {
+					fmt.Printf("profiles.%s.%s = %s\n", name, key, value)
+				}
+			}
+		}
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting (an empty value clears it)",
+	Example: "  bball config set favorite_teams knicks,celtics\n" +
+		"  bball config set timezone America/Chicago\n" +
+		"  bball config set profiles.west.favorite_teams lakers",
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, path, err := loadConfigFile()
+		if err != nil {
+			return err
+		}
+
+		if err := c.Set(args[0], args[1], validateSetting); err != nil {
+			return err
+		}
+		if err := c.Save(path); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Printf("Set %s in %s\n", args[0], path)
+		return nil
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config file in $EDITOR",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := configPath()
+		if err != nil {
+			return err
+		}
+
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(path, []byte(configTemplate), 0o644); err != nil {
+				return err
+			}
+		}
+
+		argv := append(strings.Fields(editorCommand()), path)
+		editor := exec.Command(argv[0], argv[1:]...)
+		editor.Stdin, editor.Stdout, editor.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := editor.Run(); err != nil {
+			return fmt.Errorf("editor failed: %w", err)
+		}
+
+		if _, err := config.Load(path); err != nil {
+			return fmt.Errorf("the config file has a problem, run bball config edit to fix it: %w", err)
+		}
+		return nil
+	},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the config file location",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := configPath()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	},
+}
+
+// loadConfigFile reads the raw config file, without applying a profile.
+func loadConfigFile() (*config.Config, string, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, "", err
+	}
+	c, err := config.Load(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return c, path, nil
+}
+
+// validateSetting checks values whose meaning lives outside the config package.
+func validateSetting(key, value string) error {
+	switch key {
+	case "favorite_teams":
+		for _, team := range strings.Split(value, ",") {
+			if strings.TrimSpace(team) == "" {
+				continue
+			}
+			if _, err := nba.Resolve(team); err != nil {
+				return err
+			}
+		}
+	case "timezone":
+		switch strings.ToLower(value) {
+		case "local", "arena":
+			return nil
+		}
+		if _, err := time.LoadLocation(value); err != nil {
+			return fmt.Errorf("unknown time zone %q (use local, arena, or a name like America/Chicago)", value)
+		}
+	case "output":
+		_, err := output.ParseFormat(value)
+		return err
+	}
+	return nil
+}
+
+// editorCommand returns $VISUAL, $EDITOR, or a platform default.
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if e := os.Getenv(env); e != "" {
+			return e
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// isConfigCommand reports whether cmd is one of the config subcommands.
+func isConfigCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == configCmd {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd, configSetCmd, configEditCmd, configPathCmd)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+// Exit codes returned by Execute so scripts can tell failures apart.
+const (
+	exitError       = 1 // usage errors and anything not listed below
+	exitNetwork     = 3 // nba.com could not be reached
+	exitUpstream    = 4 // nba.com answered with an error status
+	exitRateLimited = 5 // nba.com is throttling requests
+	exitDecode      = 6 // nba.com sent data we could not read
+)
+
+// describeError turns err into an actionable message and an exit code.
+func describeError(err error) (string, int) {
+	var httpErr *nba.HTTPError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, nba.ErrRateLimited):
+		return "nba.com is rate limiting requests. Wait a minute and try again.", exitRateLimited
+	case errors.As(err, &httpErr):
+		code, text := httpErr.StatusCode, http.StatusText(httpErr.StatusCode)
+		if code >= 500 {
+			return fmt.Sprintf("nba.com is having trouble right now (%d %s). Try again shortly.", code, text), exitUpstream
+		}
+		if code == http.StatusForbidden {
+			return fmt.Sprintf("nba.com refused the request (%d %s). This is usually temporary blocking by the CDN; try again later or from another network.", code, text), exitUpstream
+		}
+		return fmt.Sprintf("nba.com returned %d %s for %s.", code, text, httpErr.URL), exitUpstream
+	case errors.Is(err, nba.ErrNotCached):
+		return "Nothing cached to use offline yet. Run the command once with a connection (and without --no-cache) first.", exitNetwork
+	case errors.Is(err, nba.ErrDecode):
+		return "nba.com sent data bball couldn't read. The feed may be mid-update or its format may have changed; try again shortly.", exitDecode
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return "Couldn't reach nba.com. Check your internet connection and try again.", exitNetwork
+	}
+	return err.Error(), exitError
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestDescribeError_ExitCodes(t *testing.T) {
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{errors.New("please specify a team name"), exitError},
+		{fmt.Errorf("failed to fetch schedule: %w", &nba.HTTPError{StatusCode: 503}), exitUpstream},
+		{fmt.Errorf("failed to fetch schedule: %w", &nba.HTTPError{StatusCode: 429}), exitRateLimited},
+		{fmt.Errorf("failed to parse scoreboard: %w", nba.ErrDecode), exitDecode},
+		{fmt.Errorf("failed to fetch schedule: %w", nba.ErrNotCached), exitNetwork},
+	}
+	for _, tc := range cases {
+		if _, got := describeError(tc.err); got != tc.want {
+			t.Errorf("describeError(%v) exit code = %d, want %d", tc.err, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/internetdrew/bball/internal/ics"
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/spf13/cobra"
+)
+
+var exportFile string
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export schedules to other formats",
+}
+
+var exportICSCmd = &cobra.Command{
+	Use:   "ics [team...]",
+	Short: "Export teams' season schedules as an iCalendar (.ics) file",
+	Long: "Write every game of each team's season as calendar events.\n\n" +
+		"Events keep the same UID across exports, so re-exporting to the same --file updates\n" +
+		"rescheduled or postponed games in place instead of adding duplicates. Writing to stdout\n" +
+		"(the default) can't see the previous export, so every event starts over at SEQUENCE 0\n" +
+		"and calendar apps may keep their old copy; use --file to update a calendar in place.\n" +
+		"Without a team, exports all favorite teams from the config file.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		queries := args
+		if len(queries) == 0 {
+			queries = settings.FavoriteTeams
+		}
+		if len(queries) == 0 {
+			return fmt.Errorf("please specify a team name, or set a favorite with: bball config set favorite_teams <team>")
+		}
+
+		var teams []*nba.Franchise
+		for _, q := range queries {
+			franchise, err := resolveTeam(q)
+			if err != nil {
+				return err
+			}
+			teams = append(teams, franchise)
+		}
+
+		return exportICS(cmd.Context(), cmd.OutOrStdout(), newClient(), teams, exportFile)
+	},
+}
+
+// exportICS writes the season schedules of teams to path as an iCalendar
+// file, merging with the events already in it. A path of "-" writes to
+// stdout instead, where there is nothing to merge with.
+func exportICS(ctx context.Context, out io.Writer, client *nba.Client, teams []*nba.Franchise, path string) error {
+	var games []nba.Game
+	seen := map[string]bool{}
+	names := make([]string, len(teams))
+	for i, team := range teams {
+		names[i] = team.FullName()
+
+		season, err := client.FetchTeamSchedule(ctx, team.Tricode, "season")
+		if err != nil {
+			return err
+		}
+		for _, g := range season {
+			if !seen[g.ID] {
+				seen[g.ID] = true
+				games = append(games, g)
+			}
+		}
+	}
+
+	cal := ics.GameCalendar(strings.Join(names, ", "), games)
+	if path == "-" {
+		merged, _ := ics.Merge(nil, cal, time.Now())
+		return ics.Write(out, merged)
+	}
+
+	changes, err := ics.UpdateFile(path, cal, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to write calendar: %w", err)
+	}
+	fmt.Fprintf(out, "Exported %d game(s) to %s (%d new, %d updated).\n", len(cal.Events), path, changes.Added, changes.Updated)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportICSCmd)
+	exportICSCmd.Flags().StringVarP(&exportFile, "file", "f", "-", `File to create or update ("-" for stdout)`)
+}
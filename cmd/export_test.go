@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestExportICS_UpdatesInPlace(t *testing.T) {
+	schedule := `{"leagueSchedule":{"gameDates":[{"gameDate":"10/22/2024 00:00:00","games":[
+		{"gameId":"0022400061","gameStatus":1,"gameDateTimeUTC":"%s",
+		 "homeTeam":{"teamTricode":"BOS","teamName":"Celtics"},"awayTeam":{"teamTricode":"NYK","teamName":"Knicks"}}]}]}}`
+	start := "2024-10-22T23:30:00Z"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Replace(schedule, "%s", start, 1)))
+	}))
+	defer server.Close()
+
+	client := nba.NewClient()
+	client.LeagueScheduleURL = server.URL
+	teams := []*nba.Franchise{nba.TeamByTricode("BOS"), nba.TeamByTricode("NYK")}
+	path := filepath.Join(t.TempDir(), "games.ics")
+
+	var out bytes.Buffer
+	if err := exportICS(context.Background(), &out, client, teams, path); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "1 game(s)") || !strings.Contains(out.String(), "1 new") {
+		t.Fatalf("unexpected report %q", out.String())
+	}
+
+	start = "2024-10-23T00:00:00Z" // rescheduled
+	out.Reset()
+	if err := exportICS(context.Background(), &out, client, teams, path); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "0 new, 1 updated") {
+		t.Fatalf("unexpected report %q", out.String())
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Count(string(data), "BEGIN:VEVENT") != 1 || !strings.Contains(string(data), "DTSTART:20241023T000000Z") {
+		t.Fatalf("expected one rescheduled event:\n%s", data)
+	}
+}
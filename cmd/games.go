@@ -1,35 +1,74 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/output"
 	"github.com/internetdrew/bball/internal/util"
 	"github.com/spf13/cobra"
 )
 
 var (
-	liveOnly  bool
-	finalOnly bool
+	liveOnly    bool
+	finalOnly   bool
+	gamesDate   string
+	gamesWatch  time.Duration
+	gamesDetail bool
 )
 
 var gamesCmd = &cobra.Command{
 	Use:   "games",
 	Short: "Show all NBA games for today",
-	Long:  "Display a list of all NBA games scheduled for today, with optional filters for live or completed games.",
+	Long: "Display a list of all NBA games scheduled for today, with optional filters for live or completed games.\n\n" +
+		"Use --date YYYY-MM-DD (or yesterday/tomorrow) to see any day's slate from the league schedule.\n" +
+		"Use --detail for the quarter-by-quarter linescore under live and final games; period scores come\n" +
+		"from the live scoreboard, so they're only there for today's games.",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		scoreboard, err := nba.FetchScoreboard()
+		day, isToday, err := parseGamesDate(gamesDate, time.Now().In(util.Location))
 		if err != nil {
-			return fmt.Errorf("failed to fetch games: %w", err)
+			return err
 		}
 
-		if len(scoreboard.Scoreboard.Games) == 0 {
-			fmt.Println("No games scheduled for today.")
-			return nil
+		client := newClient()
+
+		if gamesWatch > 0 {
+			if !isToday {
+				return fmt.Errorf("--watch only works for today's games")
+			}
+			return watchGames(cmd.Context(), client, day, gamesWatch)
+		}
+
+		var allGames []nba.Game
+		if isToday {
+			allGames, err = fetchGamesToday(cmd.Context(), client, day)
+		} else {
+			allGames, err = client.FetchGamesOn(cmd.Context(), day)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch games: %w", err)
 		}
 
 		// Filter games based on flags
-		games := filterGames(scoreboard.Scoreboard.Games)
+		games := filterGames(allGames)
+
+		if structuredOutput() {
+			if games == nil {
+				games = []nba.Game{}
+			}
+			return render(cmd, games, output.GamesTable(games))
+		}
+
+		if len(allGames) == 0 {
+			if isToday {
+				fmt.Println("No games scheduled for today.")
+			} else {
+				fmt.Printf("No games scheduled for %s.\n", day.Format("Mon Jan 2, 2006"))
+			}
+			return nil
+		}
 
 		if len(games) == 0 {
 			if liveOnly {
@@ -40,11 +79,76 @@ var gamesCmd = &cobra.Command{
 			return nil
 		}
 
-		fmt.Println(util.FormatGamesList(games))
+		fmt.Println(formatGames(games, nil))
 		return nil
 	},
 }
 
+// fetchGamesToday returns day's games from the live scoreboard, or from the
+// league schedule while the scoreboard still shows the night before's.
+func fetchGamesToday(ctx context.Context, client *nba.Client, day time.Time) ([]nba.Game, error) {
+	scoreboard, err := client.FetchScoreboard(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if scoreboard.IsFor(day) {
+		return scoreboard.Scoreboard.Games, nil
+	}
+	return client.FetchGamesOn(ctx, day)
+}
+
+// watchGames redraws today's games every interval, highlighting what
+// changed, until every game is final.
+func watchGames(ctx context.Context, client *nba.Client, day time.Time, interval time.Duration) error {
+	trackers := map[string]*nba.GameTracker{}
+
+	return runWatch(ctx, interval, func(ctx context.Context) (watchFrame, error) {
+		all, err := fetchGamesToday(ctx, client, day)
+		if err != nil {
+			return watchFrame{}, fmt.Errorf("failed to fetch games: %w", err)
+		}
+
+		changes := map[string]nba.GameChanges{}
+		live, over := false, 0
+		for _, g := range all {
+			if tracker, ok := trackers[g.ID]; ok {
+				changes[g.ID] = tracker.Update(g)
+			} else {
+				trackers[g.ID] = nba.NewGameTracker(g)
+			}
+			live = live || g.State().IsLive()
+			if g.State().IsDone() {
+				over++
+			}
+		}
+		done := len(all) > 0 && over == len(all)
+
+		games := filterGames(all)
+		if len(games) == 0 {
+			return watchFrame{output: "No matching games right now.\n", live: live, done: done}, nil
+		}
+		return watchFrame{output: formatGames(games, changes), live: live, done: done}, nil
+	})
+}
+
+// formatGames renders games as a list, with linescores under --detail.
+func formatGames(games []nba.Game, changes map[string]nba.GameChanges) string {
+	if gamesDetail {
+		return util.FormatGamesListDetail(games, changes)
+	}
+	return util.FormatGamesListChanges(games, changes)
+}
+
+// parseGamesDate interprets the --date flag relative to now. It accepts
+// YYYY-MM-DD, "today", "yesterday" and "tomorrow"; empty means today.
+func parseGamesDate(value string, now time.Time) (day time.Time, isToday bool, err error) {
+	day, isToday, err = util.ParseDay(value, now)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid --date %q: use YYYY-MM-DD, yesterday, or tomorrow", value)
+	}
+	return day, isToday, nil
+}
+
 func filterGames(games []nba.Game) []nba.Game {
 	if !liveOnly && !finalOnly {
 		return games
@@ -52,10 +156,9 @@ func filterGames(games []nba.Game) []nba.Game {
 
 	filtered := []nba.Game{}
 	for _, g := range games {
-		// GameStatus: 1 = Scheduled, 2 = Live, 3 = Final
-		if liveOnly && g.GameStatus == 2 {
+		if liveOnly && g.State().IsLive() {
 			filtered = append(filtered, g)
-		} else if finalOnly && g.GameStatus == 3 {
+		} else if finalOnly && g.State().IsFinal() {
 			filtered = append(filtered, g)
 		}
 	}
@@ -66,4 +169,7 @@ func init() {
 	rootCmd.AddCommand(gamesCmd)
 	gamesCmd.Flags().BoolVarP(&liveOnly, "live", "l", false, "Show only live games")
 	gamesCmd.Flags().BoolVarP(&finalOnly, "final", "f", false, "Show only completed games")
+	gamesCmd.Flags().StringVarP(&gamesDate, "date", "d", "", "Show games on YYYY-MM-DD, yesterday, or tomorrow")
+	gamesCmd.Flags().BoolVar(&gamesDetail, "detail", false, "Show the linescore under live and final games")
+	addWatchFlag(gamesCmd, &gamesWatch)
 }
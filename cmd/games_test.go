@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/internetdrew/bball/internal/nba"
 )
@@ -52,3 +56,56 @@ func TestFilterGames_FinalOnly(t *testing.T) {
 		}
 	})
 }
+
+func TestParseGamesDate(t *testing.T) {
+	now := time.Date(2026, 1, 2, 21, 30, 0, 0, time.UTC)
+
+	day, isToday, err := parseGamesDate("", now)
+	if err != nil || !isToday || day.Day() != 2 {
+		t.Fatalf("empty should mean today, got %v %v %v", day, isToday, err)
+	}
+
+	day, isToday, err = parseGamesDate("yesterday", now)
+	if err != nil || isToday || day.Format("2006-01-02") != "2026-01-01" {
+		t.Fatalf("unexpected yesterday: %v %v %v", day, isToday, err)
+	}
+
+	day, _, err = parseGamesDate("tomorrow", now)
+	if err != nil || day.Format("2006-01-02") != "2026-01-03" {
+		t.Fatalf("unexpected tomorrow: %v %v", day, err)
+	}
+
+	day, isToday, err = parseGamesDate("2025-12-25", now)
+	if err != nil || isToday || day.Format("2006-01-02") != "2025-12-25" {
+		t.Fatalf("unexpected explicit date: %v %v %v", day, isToday, err)
+	}
+
+	if _, _, err := parseGamesDate("12/25", now); err == nil {
+		t.Fatalf("expected error for malformed date")
+	}
+}
+
+func TestFetchGamesToday_FallsBackToSchedule(t *testing.T) {
+	scoreboard := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"scoreboard":{"gameDate":"2024-10-22","games":[{"gameId":"0022400061","gameStatus":3}]}}`))
+	}))
+	defer scoreboard.Close()
+	schedule := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"leagueSchedule":{"gameDates":[{"gameDate":"10/23/2024 00:00:00","games":[
+			{"gameId":"0022400071","gameStatus":1,"gameDateTimeUTC":"2024-10-23T23:30:00Z"}]}]}}`))
+	}))
+	defer schedule.Close()
+
+	client := nba.NewClient()
+	client.ScoreboardURL = scoreboard.URL
+	client.LeagueScheduleURL = schedule.URL
+
+	games, err := fetchGamesToday(context.Background(), client, time.Date(2024, 10, 22, 0, 0, 0, 0, time.UTC))
+	if err != nil || len(games) != 1 || games[0].ID != "0022400061" {
+		t.Fatalf("expected the scoreboard's games, got %+v %v", games, err)
+	}
+	games, err = fetchGamesToday(context.Background(), client, time.Date(2024, 10, 23, 0, 0, 0, 0, time.UTC))
+	if err != nil || len(games) != 1 || games[0].ID != "0022400071" {
+		t.Fatalf("expected the schedule's games before the rollover, got %+v %v", games, err)
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/internetdrew/bball/internal/output"
+	"github.com/internetdrew/bball/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var h2hCmd = &cobra.Command{
+	Use:   "h2h [teamA] <teamB>",
+	Short: "Show the season series between two teams",
+	Long: "List every meeting between two teams this season: scores and the winner for completed games,\n" +
+		"dates for upcoming ones, the running series record, point differential, home/away split,\n" +
+		"and the next meeting. With one team, compares it against your first favorite team.",
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		queryA, err := teamArg(args[:len(args)-1])
+		if err != nil {
+			return err
+		}
+		teamA, err := resolveTeam(queryA)
+		if err != nil {
+			return err
+		}
+		teamB, err := resolveTeam(args[len(args)-1])
+		if err != nil {
+			return err
+		}
+		if teamA.ID == teamB.ID {
+			return fmt.Errorf("pick two different teams, not the %s twice", teamA.FullName())
+		}
+
+		schedule, err := newClient().FetchLeagueSchedule(cmd.Context())
+		if err != nil {
+			return err
+		}
+		h := schedule.HeadToHead(teamA, teamB)
+
+		if structuredOutput() {
+			return render(cmd, h, output.HeadToHeadTable(h))
+		}
+
+		fmt.Print(util.FormatHeadToHead(h))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(h2hCmd)
+}
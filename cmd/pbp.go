@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/output"
+	"github.com/internetdrew/bball/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pbpPeriod int
+	pbpLast   int
+	pbpTeam   string
+	pbpType   string
+)
+
+var pbpCmd = &cobra.Command{
+	Use:   "pbp [team|gameId]",
+	Short: "Show the play-by-play for a game",
+	Long: "Display the play-by-play for today's game involving a team, or for a specific game ID.\n\n" +
+		"Filter with --period, --team, --type (shot, made, missed, rebound, foul, turnover, sub, timeout, period; comma-separated) and --last N.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kinds, err := nba.ParseActionKinds(pbpType)
+		if err != nil {
+			return err
+		}
+
+		team := ""
+		if pbpTeam != "" {
+			franchise, err := resolveTeam(pbpTeam)
+			if err != nil {
+				return err
+			}
+			team = franchise.Tricode
+		}
+
+		client := newClient()
+
+		query, err := teamArg(args)
+		if err != nil {
+			return err
+		}
+
+		gameID, err := resolveGameID(cmd.Context(), client, query)
+		if err != nil {
+			return err
+		}
+		if gameID == "" {
+			if structuredOutput() {
+				return render(cmd, []nba.Action{}, output.ActionsTable(nil))
+			}
+			fmt.Println("No game today for that team.")
+			return nil
+		}
+
+		pbp, err := client.FetchPlayByPlay(cmd.Context(), gameID)
+		if err != nil {
+			if isNotAvailableYet(err) && !structuredOutput() {
+				fmt.Println("Play-by-play isn't available yet. It appears shortly before tip-off.")
+				return nil
+			}
+			return err
+		}
+
+		actions := nba.FilterActions(pbp.Game.Actions, nba.ActionFilter{
+			Period: pbpPeriod,
+			Team:   team,
+			Kinds:  kinds,
+			LastN:  pbpLast,
+		})
+		if structuredOutput() {
+			if actions == nil {
+				actions = []nba.Action{}
+			}
+			return render(cmd, actions, output.ActionsTable(actions))
+		}
+
+		if len(actions) == 0 {
+			fmt.Println("No plays match those filters.")
+			return nil
+		}
+
+		fmt.Print(util.FormatPlayByPlay(actions))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pbpCmd)
+	pbpCmd.Flags().IntVarP(&pbpPeriod, "period", "p", 0, "Only show plays from this period (5 = OT)")
+	pbpCmd.Flags().IntVarP(&pbpLast, "last", "n", 0, "Only show the last N plays")
+	pbpCmd.Flags().StringVarP(&pbpTeam, "team", "t", "", "Only show plays by this team")
+	pbpCmd.Flags().StringVar(&pbpType, "type", "", "Only show these action types (comma-separated)")
+}
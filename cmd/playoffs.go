@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/internetdrew/bball/internal/output"
+	"github.com/internetdrew/bball/internal/standings"
+	"github.com/internetdrew/bball/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var playoffsCmd = &cobra.Command{
+	Use:   "playoffs",
+	Short: "Show the playoff picture",
+	Long:  "See where each conference's postseason stands, computed from the league schedule like standings.",
+}
+
+var playoffsPictureCmd = &cobra.Command{
+	Use:   "picture",
+	Short: "Show seeds, the play-in and who's out if the season ended today",
+	Long: "Seed each conference from the standings: 1-6 go to the playoffs, 7-10 to the play-in tournament,\n" +
+		"and the rest are out. Teams are marked once it's settled:\n\n" +
+		"  z  clinched the conference's No. 1 seed\n" +
+		"  y  clinched the division\n" +
+		"  x  clinched a playoff spot (top six)\n" +
+		"  o  play-in at best (can't finish in the top six)\n" +
+		"  e  eliminated (can't finish in the top ten)\n\n" +
+		"Magic and elimination numbers come from the games left on the schedule. MAGIC is how many wins,\n" +
+		"or losses by the teams behind, lock in the part of the picture a team is in now; ELIM is how many\n" +
+		"losses, or wins by the teams ahead, end its chase for the part above. Ties count against a team,\n" +
+		"so nothing is called clinched that a tiebreaker could undo.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schedule, err := fetchScheduleOrCached(cmd.Context(), newClient())
+		if err != nil {
+			return err
+		}
+		pictures := standings.PlayoffPicture(standings.Results(schedule), standings.Remaining(schedule))
+
+		if structuredOutput() {
+			return render(cmd, pictures, output.PlayoffPictureTable(pictures))
+		}
+
+		fmt.Print(util.FormatPlayoffPicture(pictures))
+		return nil
+	},
+}
+
+var playoffsBracketCmd = &cobra.Command{
+	Use:   "bracket",
+	Short: "Show the playoff bracket with series results",
+	Long: "Draw each conference's bracket from the playoff games on the schedule, with every team's wins\n" +
+		"in each round, then the Finals. Rounds not set yet show TBD.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schedule, err := fetchScheduleOrCached(cmd.Context(), newClient())
+		if err != nil {
+			return err
+		}
+		series := schedule.PlayoffSeries()
+
+		if structuredOutput() {
+			return render(cmd, series, output.BracketTable(series))
+		}
+
+		fmt.Print(util.FormatBracket(series))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(playoffsCmd)
+	playoffsCmd.AddCommand(playoffsPictureCmd)
+	playoffsCmd.AddCommand(playoffsBracketCmd)
+}
@@ -3,19 +3,186 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/fatih/color"
+	"github.com/internetdrew/bball/internal/cache"
+	"github.com/internetdrew/bball/internal/config"
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/output"
+	"github.com/internetdrew/bball/internal/util"
 	"github.com/spf13/cobra"
 )
 
+var (
+	noCache      bool
+	offline      bool
+	outputFlag   string
+	outputFormat = output.Text
+	tzFlag       string
+	configFlag   string
+	profileFlag  string
+
+	// settings is the config file with the selected profile applied.
+	settings = &config.Config{}
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "bball",
 	Short: "Catch up on NBA games from your terminal",
 	Long:  "A fast CLI tool for checking live NBA scores, today's games, and your favorite team's recent and upcoming matchups.",
+	// Errors are reported by Execute so they can be mapped to exit codes.
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Arguments are valid by now; don't print usage for runtime failures.
+		cmd.SilenceUsage = true
+
+		if err := loadSettings(); err != nil {
+			// Let the config commands run so a broken file can be fixed.
+			if !isConfigCommand(cmd) {
+				return err
+			}
+			fmt.Fprintln(os.Stderr, "warning:", err)
+		}
+
+		// Flags win over environment variables, which win over the config file.
+		format, err := output.ParseFormat(setting(cmd, "output", outputFlag, "", settings.Output))
+		if err != nil {
+			return err
+		}
+		outputFormat = format
+		if structuredOutput() || settings.Theme == "mono" {
+			color.NoColor = true
+		}
+
+		return util.SetTimezone(setting(cmd, "tz", tzFlag, "BBALL_TZ", settings.Timezone))
+	},
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		msg, code := describeError(err)
+		fmt.Fprintln(os.Stderr, msg)
+		os.Exit(code)
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Bypass the on-disk response cache")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "Use only cached responses, however old, without contacting nba.com")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "text", "Output format: text, json, yaml, csv, or tsv")
+	rootCmd.PersistentFlags().StringVar(&tzFlag, "tz", "local", "Time zone for dates and tip-off times: local, arena, or an IANA name (env BBALL_TZ)")
+	rootCmd.PersistentFlags().StringVar(&configFlag, "config", "", "Config file (default $XDG_CONFIG_HOME/bball/config.yaml, env BBALL_CONFIG)")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Config profile to use (env BBALL_PROFILE)")
+}
+
+// configPath returns the config file selected by --config or the default.
+func configPath() (string, error) {
+	if configFlag != "" {
+		return configFlag, nil
+	}
+	return config.DefaultPath()
+}
+
+// loadSettings reads the config file and applies the selected profile.
+func loadSettings() error {
+	path, err := configPath()
+	if err != nil {
+		return nil // no config directory; run with defaults
+	}
+
+	file, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	profile := profileFlag
+	if profile == "" {
+		profile = os.Getenv("BBALL_PROFILE")
+	}
+	merged, err := file.WithProfile(profile)
+	if err != nil {
+		return err
+	}
+	settings = merged
+	return nil
+}
+
+// setting picks a value in precedence order: the flag if it was set, the
+// environment variable, the config file, then the flag's default.
+func setting(cmd *cobra.Command, flag, flagValue, env, configValue string) string {
+	if cmd.Flags().Changed(flag) {
+		return flagValue
+	}
+	if env != "" && os.Getenv(env) != "" {
+		return os.Getenv(env)
+	}
+	if configValue != "" {
+		return configValue
+	}
+	return flagValue
+}
+
+// newClient returns the NBA client shared by the commands.
+func newClient() *nba.Client {
+	client := nba.NewClient()
+	if !noCache && settings.CacheEnabled() {
+		client.Cache = openCache()
+	}
+	// config.Load has already rejected TTLs that don't parse.
+	if ttl, err := config.ParseTTL(settings.Cache.ScheduleTTL, client.ScheduleTTL); err == nil {
+		client.ScheduleTTL = ttl
+	}
+	if ttl, err := config.ParseTTL(settings.Cache.ScoreboardTTL, client.ScoreboardTTL); err == nil {
+		client.ScoreboardTTL = ttl
+	}
+	if settings.ScheduleWindow > 0 {
+		client.ScheduleWindow = settings.ScheduleWindow
+	}
+	client.Offline = offline
+	return client
+}
+
+// structuredOutput reports whether results go to a script (--output json,
+// yaml, csv or tsv) rather than a person.
+func structuredOutput() bool {
+	return outputFormat != output.Text
+}
+
+// render writes data in the selected structured format.
+func render(cmd *cobra.Command, data any, table output.Table) error {
+	return output.Render(cmd.OutOrStdout(), outputFormat, data, table)
+}
+
+// resolveTeam maps a user-supplied team name to a franchise, reporting
+// ambiguous or unknown names.
+func resolveTeam(query string) (*nba.Franchise, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("please specify a team name")
+	}
+	return nba.Resolve(query)
+}
+
+// teamArg returns the team named on the command line, falling back to the
+// first favorite team from the config file.
+func teamArg(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if fav := settings.FavoriteTeam(); fav != "" {
+		return fav, nil
+	}
+	return "", fmt.Errorf("please specify a team name, or set a favorite with: bball config set favorite_teams <team>")
+}
+
+// openCache returns the on-disk cache, or nil if no cache directory is available.
+func openCache() *cache.Cache {
+	if dir := settings.Cache.Directory(); dir != "" {
+		return cache.New(dir)
+	}
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return nil
 	}
+	return cache.New(dir)
 }
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/internetdrew/bball/internal/config"
+)
+
+func TestTeamArg_FallsBackToFavorite(t *testing.T) {
+	old := settings
+	defer func() { settings = old }()
+
+	settings = &config.Config{}
+	if _, err := teamArg(nil); err == nil {
+		t.Fatalf("expected error without a team or favorite")
+	}
+
+	settings = &config.Config{FavoriteTeams: []string{"knicks", "celtics"}}
+	if got, _ := teamArg(nil); got != "knicks" {
+		t.Fatalf("expected first favorite, got %q", got)
+	}
+	if got, _ := teamArg([]string{"heat"}); got != "heat" {
+		t.Fatalf("explicit team should win, got %q", got)
+	}
+}
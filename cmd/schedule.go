@@ -2,27 +2,38 @@ package cmd
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/output"
 	"github.com/internetdrew/bball/internal/util"
 	"github.com/spf13/cobra"
 )
 
 var (
-	upcoming bool
-	recent   bool
+	upcoming    bool
+	recent      bool
+	scheduleICS string
 )
 
 var scheduleCmd = &cobra.Command{
 	Use:   "schedule [team]",
 	Short: "View a team's schedule",
-	Long:  "Display upcoming games or recent games for a specific NBA team.\n\nUse --upcoming (-u) to see future games or --recent (-r) to see past games.\nDefaults to upcoming games if no flag is specified.",
-	Args:  cobra.ExactArgs(1),
+	Long:  "Display upcoming games or recent games for a specific NBA team.\n\nUse --upcoming (-u) to see future games or --recent (-r) to see past games.\nDefaults to upcoming games if no flag is specified.\nUse --ics FILE to export the team's whole season as calendar events instead.\nWithout a team, uses the first favorite team from the config file.",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		team := strings.TrimSpace(strings.ToLower(args[0]))
-		if team == "" {
-			return fmt.Errorf("please specify a team name")
+		query, err := teamArg(args)
+		if err != nil {
+			return err
+		}
+
+		franchise, err := resolveTeam(query)
+		if err != nil {
+			return err
+		}
+		team := franchise.Tricode
+
+		if scheduleICS != "" {
+			return exportICS(cmd.Context(), cmd.OutOrStdout(), newClient(), []*nba.Franchise{franchise}, scheduleICS)
 		}
 
 		// Default to upcoming if neither flag is set
@@ -31,18 +42,24 @@ var scheduleCmd = &cobra.Command{
 		}
 
 		var games []nba.Game
-		var err error
 
 		if upcoming {
-			games, err = nba.FetchTeamSchedule(team, "upcoming")
+			games, err = newClient().FetchTeamSchedule(cmd.Context(), team, "upcoming")
 		} else {
-			games, err = nba.FetchTeamSchedule(team, "recent")
+			games, err = newClient().FetchTeamSchedule(cmd.Context(), team, "recent")
 		}
 
 		if err != nil {
 			return err
 		}
 
+		if structuredOutput() {
+			if games == nil {
+				games = []nba.Game{}
+			}
+			return render(cmd, games, output.GamesTable(games))
+		}
+
 		if len(games) == 0 {
 			if upcoming {
 				fmt.Println("No upcoming games found for that team.")
@@ -66,4 +83,5 @@ func init() {
 	rootCmd.AddCommand(scheduleCmd)
 	scheduleCmd.Flags().BoolVarP(&upcoming, "upcoming", "u", false, "Show upcoming games")
 	scheduleCmd.Flags().BoolVarP(&recent, "recent", "r", false, "Show recent games")
+	scheduleCmd.Flags().StringVar(&scheduleICS, "ics", "", `Export the full season to an iCalendar file ("-" for stdout)`)
 }
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/internetdrew/bball/internal/server"
+	"github.com/internetdrew/bball/internal/stream"
+	"github.com/spf13/cobra"
+)
+
+var serveAddr string
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve scores and schedules as a local JSON API",
+	Long: "Run an HTTP server with read-only JSON endpoints over the same data the CLI uses:\n\n" +
+		"  GET /games?date=YYYY-MM-DD           games on a day (default today)\n" +
+		"  GET /games/{id}                      one game\n" +
+		"  GET /teams/{team}/schedule?mode=     upcoming, recent, or season\n" +
+		"  GET /teams/{team}/live               summary of a team's live game\n" +
+		"  GET /events                          live game events (Server-Sent Events)\n" +
+		"  GET /ws                              the same events over a WebSocket\n" +
+		"  GET /health                          health check\n" +
+		"  GET /openapi.json                    OpenAPI description\n\n" +
+		"All clients share one copy of the scoreboard and schedule, so nba.com is polled at most\n" +
+		"once per cache TTL, and that same copy feeds every event stream. Events carry IDs: a\n" +
+		"reconnecting client sends Last-Event-ID (or ?lastEventId= on /ws) to pick up where it\n" +
+		"left off. Ctrl-C stops the server after in-flight requests finish.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ln, err := net.Listen("tcp", serveAddr)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		client := newClient()
+		logger := log.New(os.Stderr, "", log.LstdFlags)
+
+		s := server.New(client)
+		s.Log = logger
+
+		// The hub polls the server's copy of the scoreboard, so the API and
+		// the event streams share one fetch.
+		s.Hub = stream.NewHub(s.Scoreboard)
+		s.Hub.Log = logger
+		go s.Hub.Run(ctx)
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Serving on http://%s (Ctrl-C to stop)\n", ln.Addr())
+		return s.Serve(ctx, ln)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080", "Address to listen on")
+}
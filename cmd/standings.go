@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/output"
+	"github.com/internetdrew/bball/internal/standings"
+	"github.com/internetdrew/bball/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	standingsConference bool
+	standingsDivision   bool
+	standingsExplain    bool
+)
+
+var standingsCmd = &cobra.Command{
+	Use:   "standings",
+	Short: "Show league standings",
+	Long: "Display standings computed from this season's final scores: W-L, PCT, games back, home and road\n" +
+		"records, conference and division records, last 10, and streak. Teams with the same record are\n" +
+		"ordered by the NBA's tiebreakers (head-to-head, division leader, division and conference record,\n" +
+		"record vs playoff teams, point differential); --explain shows which rule decided each tie.\n\n" +
+		"Shows each conference by default; use --division for the six divisions. Standings only need the\n" +
+		"league schedule, so they work offline from the cache (--offline), and fall back to it when\n" +
+		"nba.com can't be reached.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		view := standings.Conference
+		if standingsDivision {
+			view = standings.Division
+		}
+
+		schedule, err := fetchScheduleOrCached(cmd.Context(), newClient())
+		if err != nil {
+			return err
+		}
+		groups := standings.Standings(standings.Results(schedule), view)
+		if !standingsExplain {
+			for i := range groups {
+				groups[i].Tiebreaks = nil
+			}
+		}
+
+		if structuredOutput() {
+			return render(cmd, groups, output.StandingsTable(groups))
+		}
+
+		fmt.Print(util.FormatStandings(groups, standingsExplain))
+		return nil
+	},
+}
+
+// fetchScheduleOrCached fetches the league schedule, falling back to the
+// cached copy, however old, when nba.com can't be reached.
+func fetchScheduleOrCached(ctx context.Context, client *nba.Client) (*nba.LeagueScheduleResponse, error) {
+	schedule, err := client.FetchLeagueSchedule(ctx)
+	if err == nil || client.Offline || client.Cache == nil {
+		return schedule, err
+	}
+	if _, code := describeError(err); code != exitNetwork {
+		return nil, err
+	}
+
+	client.Offline = true
+	schedule, cacheErr := client.FetchLeagueSchedule(ctx)
+	if cacheErr != nil {
+		return nil, err
+	}
+	if entry, _, err := client.Cache.Get(client.LeagueScheduleURL); err == nil {
+		fmt.Fprintf(os.Stderr, "Couldn't reach nba.com; using the schedule cached %s.\n", util.FormatAge(entry.Age()))
+	}
+	return schedule, nil
+}
+
+func init() {
+	rootCmd.AddCommand(standingsCmd)
+	standingsCmd.Flags().BoolVar(&standingsConference, "conference", false, "Group by conference (the default)")
+	standingsCmd.Flags().BoolVar(&standingsDivision, "division", false, "Group by division")
+	standingsCmd.Flags().BoolVar(&standingsExplain, "explain", false, "Show how each tie was broken")
+	standingsCmd.MarkFlagsMutuallyExclusive("conference", "division")
+}
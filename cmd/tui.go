@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Full-screen dashboard of today's games",
+	Long: "Open a keyboard-driven dashboard with today's games in a grid that refreshes itself.\n\n" +
+		"Arrow keys (or h/j/k/l) move between games, Enter opens a game's summary, leaders and linescore,\n" +
+		"and Tab switches to a team's schedule, starting with the first favorite team from the config file.\n" +
+		"Press q to quit.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if structuredOutput() {
+			return fmt.Errorf("bball tui can't be combined with --output %s", outputFormat)
+		}
+
+		var favorite *nba.Franchise
+		if fav := settings.FavoriteTeam(); fav != "" {
+			franchise, err := resolveTeam(fav)
+			if err != nil {
+				return err
+			}
+			favorite = franchise
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+		defer stop()
+
+		return tui.Run(ctx, os.Stdin, os.Stdout, tui.Options{
+			Client:   newClient(),
+			Favorite: favorite,
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultWatchInterval = 30 * time.Second
+	// minWatchInterval keeps a redraw from coming around before the cached
+	// scoreboard it shows can have changed.
+	minWatchInterval = nba.DefaultScoreboardTTL
+	// idleWatchInterval caps the backoff while nothing is live.
+	idleWatchInterval = 5 * time.Minute
+)
+
+// watchFrame is the result of one refresh of a watched view.
+type watchFrame struct {
+	output string
+	live   bool // a watched game is in progress
+	done   bool // stop after drawing this frame
+}
+
+// addWatchFlag registers --watch on cmd. A bare --watch polls every 30s;
+// --watch=15s picks the interval.
+func addWatchFlag(cmd *cobra.Command, interval *time.Duration) {
+	cmd.Flags().DurationVarP(interval, "watch", "w", 0, "Refresh continuously (optionally --watch=15s)")
+	cmd.Flags().Lookup("watch").NoOptDefVal = defaultWatchInterval.String()
+}
+
+// runWatch redraws the output of refresh until it reports done or the user
+// presses Ctrl-C. While nothing is live the interval doubles up to
+// idleWatchInterval; it snaps back once a game is in progress.
+func runWatch(ctx context.Context, interval time.Duration, refresh func(ctx context.Context) (watchFrame, error)) error {
+	if structuredOutput() {
+		return fmt.Errorf("--watch can't be combined with --output %s", outputFormat)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if interval < minWatchInterval {
+		interval = minWatchInterval
+	}
+	faint := color.New(color.Faint).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	wait := interval
+	for {
+		frame, err := refresh(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		clearScreen()
+		if err != nil {
+			msg, _ := describeError(err)
+			fmt.Println(yellow("⚠ " + msg))
+		} else {
+			fmt.Print(frame.output)
+			if frame.done {
+				return nil
+			}
+		}
+
+		if err == nil && frame.live {
+			wait = interval
+		} else if wait *= 2; wait > idleWatchInterval {
+			wait = idleWatchInterval
+		}
+
+		fmt.Println(faint(fmt.Sprintf("Updated %s · next refresh in %s · Ctrl-C to stop",
+			time.Now().Format("3:04:05 PM"), wait)))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// clearScreen moves the cursor home and clears the terminal so each frame
+// redraws in place. When output is piped, frames are separated instead.
+func clearScreen() {
+	if isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd()) {
+		fmt.Print("\033[H\033[2J")
+		return
+	}
+	fmt.Println()
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRunWatch_StopsWhenDone(t *testing.T) {
+	calls := 0
+	err := runWatch(context.Background(), minWatchInterval, func(ctx context.Context) (watchFrame, error) {
+		calls++
+		return watchFrame{output: "final\n", done: true}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single refresh, got %d", calls)
+	}
+}
+
+func TestRunWatch_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := runWatch(ctx, minWatchInterval, func(ctx context.Context) (watchFrame, error) {
+		cancel()
+		return watchFrame{output: "live\n", live: true}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
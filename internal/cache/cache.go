@@ -0,0 +1,197 @@
+// Package cache stores HTTP response bodies on disk together with the
+// validators (ETag, Last-Modified) needed to revalidate them.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	metaExt = ".meta.json"
+	bodyExt = ".body"
+)
+
+// Entry describes a cached response.
+type Entry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+	Size         int64     `json:"size"`
+}
+
+// Age reports how long ago the entry was fetched or last revalidated.
+func (e Entry) Age() time.Duration {
+	return time.Since(e.FetchedAt)
+}
+
+// Fresh reports whether the entry is younger than ttl.
+func (e Entry) Fresh(ttl time.Duration) bool {
+	return ttl > 0 && e.Age() < ttl
+}
+
+// Cache is a directory of cached responses keyed by URL.
+type Cache struct {
+	Dir string
+}
+
+// New returns a cache rooted at dir. The directory is created on first write.
+func New(dir string) *Cache {
+	return &Cache{Dir: dir}
+}
+
+// DefaultDir returns the bball directory inside the user's cache directory.
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "bball"), nil
+}
+
+func (c *Cache) path(url, ext string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:16])+ext)
+}
+
+// Get returns the cached entry and body for url. It returns os.ErrNotExist
+// when nothing usable is cached.
+func (c *Cache) Get(url string) (*Entry, []byte, error) {
+	entry, err := c.readMeta(c.path(url, metaExt))
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := os.ReadFile(c.path(url, bodyExt))
+	if err != nil {
+		return nil, nil, err
+	}
+	return entry, body, nil
+}
+
+// Put stores body and its validators for url.
+func (c *Cache) Put(url string, entry Entry, body []byte) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+	entry.URL = url
+	entry.Size = int64(len(body))
+	if entry.FetchedAt.IsZero() {
+		entry.FetchedAt = time.Now()
+	}
+	if err := writeFileAtomic(c.path(url, bodyExt), body); err != nil {
+		return err
+	}
+	return c.writeMeta(entry)
+}
+
+// Touch marks the entry for url as freshly revalidated.
+func (c *Cache) Touch(url string) error {
+	entry, err := c.readMeta(c.path(url, metaExt))
+	if err != nil {
+		return err
+	}
+	entry.FetchedAt = time.Now()
+	return c.writeMeta(*entry)
+}
+
+// List returns every cached entry, most recently fetched first.
+func (c *Cache) List() ([]Entry, error) {
+	matches, err := filepath.Glob(filepath.Join(c.Dir, "*"+metaExt))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(matches))
+	for _, m := range matches {
+		entry, err := c.readMeta(m)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].FetchedAt.After(entries[j].FetchedAt)
+	})
+	return entries, nil
+}
+
+// Prune removes entries older than maxAge and returns how many were removed.
+func (c *Cache) Prune(maxAge time.Duration) (int, error) {
+	entries, err := c.List()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, e := range entries {
+		if e.Age() <= maxAge {
+			continue
+		}
+		if err := c.remove(e.URL); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Clear removes every cached entry and returns how many were removed.
+func (c *Cache) Clear() (int, error) {
+	return c.Prune(-1)
+}
+
+func (c *Cache) remove(url string) error {
+	for _, ext := range []string{bodyExt, metaExt} {
+		if err := os.Remove(c.path(url, ext)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cache) readMeta(path string) (*Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (c *Cache) writeMeta(entry Entry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.path(entry.URL, metaExt), data)
+}
+
+// writeFileAtomic writes data to a temp file and renames it into place so a
+// concurrent reader never sees a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
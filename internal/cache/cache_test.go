@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPutGet_RoundTrip(t *testing.T) {
+	c := New(t.TempDir())
+	if err := c.Put("https://example.com/a.json", Entry{ETag: `"v1"`}, []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	entry, body, err := c.Get("https://example.com/a.json")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+	if entry.ETag != `"v1"` || entry.Size != int64(len(body)) {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	if !entry.Fresh(time.Minute) {
+		t.Fatalf("new entry should be fresh")
+	}
+}
+
+func TestGet_Missing(t *testing.T) {
+	c := New(t.TempDir())
+	if _, _, err := c.Get("https://example.com/missing.json"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected ErrNotExist, got %v", err)
+	}
+}
+
+func TestPrune_RemovesOldEntries(t *testing.T) {
+	c := New(t.TempDir())
+	c.Put("https://example.com/old.json", Entry{FetchedAt: time.Now().Add(-48 * time.Hour)}, []byte("old"))
+	c.Put("https://example.com/new.json", Entry{}, []byte("new"))
+
+	removed, err := c.Prune(24 * time.Hour)
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if removed != 1 {
+		t.Fatalf("expected 1 entry removed, got %d", removed)
+	}
+
+	entries, _ := c.List()
+	if len(entries) != 1 || entries[0].URL != "https://example.com/new.json" {
+		t.Fatalf("unexpected remaining entries: %+v", entries)
+	}
+}
+
+func TestClear(t *testing.T) {
+	c := New(t.TempDir())
+	c.Put("https://example.com/a.json", Entry{}, []byte("a"))
+	c.Put("https://example.com/b.json", Entry{}, []byte("b"))
+
+	removed, err := c.Clear()
+	if err != nil || removed != 2 {
+		t.Fatalf("expected 2 removed, got %d (%v)", removed, err)
+	}
+	if entries, _ := c.List(); len(entries) != 0 {
+		t.Fatalf("expected empty cache, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,371 @@
+// Package config reads and writes the user's bball configuration file.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the contents of the configuration file. Zero values mean "use the
+// built-in default". Profiles hold named overrides of the top-level settings.
+type Config struct {
+	FavoriteTeams  []string           `yaml:"favorite_teams,omitempty"`
+	Timezone       string             `yaml:"timezone,omitempty"`
+	Output         string             `yaml:"output,omitempty"`
+	Theme          string             `yaml:"theme,omitempty"` // "default" or "mono"
+	ScheduleWindow int                `yaml:"schedule_window,omitempty"`
+	Cache          Cache              `yaml:"cache,omitempty"`
+	Profiles       map[string]*Config `yaml:"profiles,omitempty"`
+}
+
+// Cache holds the response cache settings.
+type Cache struct {
+	Enabled       *bool  `yaml:"enabled,omitempty"`
+	Dir           string `yaml:"dir,omitempty"`
+	ScheduleTTL   string `yaml:"schedule_ttl,omitempty"`   // e.g. "6h"
+	ScoreboardTTL string `yaml:"scoreboard_ttl,omitempty"` // e.g. "10s"
+}
+
+// Directory returns the cache directory with a leading ~ expanded, or "".
+func (c Cache) Directory() string {
+	if c.Dir == "~" || strings.HasPrefix(c.Dir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(c.Dir, "~"))
+		}
+	}
+	return c.Dir
+}
+
+// Themes lists the accepted values for theme.
+var Themes = []string{"default", "mono"}
+
+// Keys lists the settings understood by Get and Set. Each may also be
+// addressed inside a profile as "profiles.<name>.<key>".
+var Keys = []string{
+	"favorite_teams",
+	"timezone",
+	"output",
+	"theme",
+	"schedule_window",
+	"cache.enabled",
+	"cache.dir",
+	"cache.schedule_ttl",
+	"cache.scoreboard_ttl",
+}
+
+// DefaultPath returns the config file location: $BBALL_CONFIG if set,
+// otherwise bball/config.yaml under the user config directory
+// ($XDG_CONFIG_HOME or ~/.config on Linux).
+func DefaultPath() (string, error) {
+	if p := os.Getenv("BBALL_CONFIG"); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bball", "config.yaml"), nil
+}
+
+// Load reads the config file at path. A missing file yields an empty config.
+// A cache TTL that ParseTTL rejects, at the top level or in a profile, makes
+// the file invalid.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if err := c.validate(""); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// validate checks the settings Set would have checked but hand edits can
+// get wrong, naming the offending key under prefix.
+func (c *Config) validate(prefix string) error {
+	for key, value := range map[string]string{
+		"cache.schedule_ttl":   c.Cache.ScheduleTTL,
+		"cache.scoreboard_ttl": c.Cache.ScoreboardTTL,
+	} {
+		if _, err := ParseTTL(value, 0); err != nil {
+			return fmt.Errorf("%s%s: %w", prefix, key, err)
+		}
+	}
+
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if p := c.Profiles[name]; p != nil {
+			if err := p.validate(prefix + "profiles." + name + "."); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Save writes c to path, creating the directory if needed.
+func (c *Config) Save(path string) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// WithProfile returns the settings with the named profile's non-empty values
+// layered on top. An empty name returns the top-level settings.
+func (c *Config) WithProfile(name string) (*Config, error) {
+	merged := *c
+	merged.Profiles = nil
+	if name == "" {
+		return &merged, nil
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok || p == nil {
+		return nil, fmt.Errorf("unknown profile %q", name)
+	}
+	if len(p.FavoriteTeams) > 0 {
+		merged.FavoriteTeams = p.FavoriteTeams
+	}
+	if p.Timezone != "" {
+		merged.Timezone = p.Timezone
+	}
+	if p.Output != "" {
+		merged.Output = p.Output
+	}
+	if p.Theme != "" {
+		merged.Theme = p.Theme
+	}
+	if p.ScheduleWindow != 0 {
+		merged.ScheduleWindow = p.ScheduleWindow
+	}
+	if p.Cache.Enabled != nil {
+		merged.Cache.Enabled = p.Cache.Enabled
+	}
+	if p.Cache.Dir != "" {
+		merged.Cache.Dir = p.Cache.Dir
+	}
+	if p.Cache.ScheduleTTL != "" {
+		merged.Cache.ScheduleTTL = p.Cache.ScheduleTTL
+	}
+	if p.Cache.ScoreboardTTL != "" {
+		merged.Cache.ScoreboardTTL = p.Cache.ScoreboardTTL
+	}
+	return &merged, nil
+}
+
+// FavoriteTeam returns the first favorite team, or "".
+func (c *Config) FavoriteTeam() string {
+	if len(c.FavoriteTeams) == 0 {
+		return ""
+	}
+	return c.FavoriteTeams[0]
+}
+
+// CacheEnabled reports whether the response cache should be used.
+func (c *Config) CacheEnabled() bool {
+	return c.Cache.Enabled == nil || *c.Cache.Enabled
+}
+
+// Get returns the value of key formatted as Set accepts it.
+func (c *Config) Get(key string) (string, error) {
+	target, key, err := c.target(key, false)
+	if err != nil {
+		return "", err
+	}
+
+	switch key {
+	case "favorite_teams":
+		return strings.Join(target.FavoriteTeams, ","), nil
+	case "timezone":
+		return target.Timezone, nil
+	case "output":
+		return target.Output, nil
+	case "theme":
+		return target.Theme, nil
+	case "schedule_window":
+		if target.ScheduleWindow == 0 {
+			return "", nil
+		}
+		return strconv.Itoa(target.ScheduleWindow), nil
+	case "cache.enabled":
+		if target.Cache.Enabled == nil {
+			return "", nil
+		}
+		return strconv.FormatBool(*target.Cache.Enabled), nil
+	case "cache.dir":
+		return target.Cache.Dir, nil
+	case "cache.schedule_ttl":
+		return target.Cache.ScheduleTTL, nil
+	case "cache.scoreboard_ttl":
+		return target.Cache.ScoreboardTTL, nil
+	}
+	return "", unknownKey(key)
+}
+
+// Set parses value for key. An empty value clears the setting. validate is
+// called with the key and value for settings that need checking beyond their
+// type, such as time zones and output formats.
+func (c *Config) Set(key, value string, validate func(key, value string) error) error {
+	target, key, err := c.target(key, true)
+	if err != nil {
+		return err
+	}
+	value = strings.TrimSpace(value)
+
+	if value != "" && validate != nil {
+		if err := validate(key, value); err != nil {
+			return err
+		}
+	}
+
+	switch key {
+	case "favorite_teams":
+		target.FavoriteTeams = nil
+		for _, t := range strings.Split(value, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				target.FavoriteTeams = append(target.FavoriteTeams, t)
+			}
+		}
+	case "timezone":
+		target.Timezone = value
+	case "output":
+		target.Output = value
+	case "theme":
+		if value != "" && !contains(Themes, value) {
+			return fmt.Errorf("unknown theme %q (use %s)", value, strings.Join(Themes, " or "))
+		}
+		target.Theme = value
+	case "schedule_window":
+		if value == "" {
+			target.ScheduleWindow = 0
+			return nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("schedule_window must be a positive number")
+		}
+		target.ScheduleWindow = n
+	case "cache.enabled":
+		if value == "" {
+			target.Cache.Enabled = nil
+			return nil
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("cache.enabled must be true or false")
+		}
+		target.Cache.Enabled = &b
+	case "cache.dir":
+		target.Cache.Dir = value
+	case "cache.schedule_ttl", "cache.scoreboard_ttl":
+		if _, err := ParseTTL(value, 0); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if key == "cache.schedule_ttl" {
+			target.Cache.ScheduleTTL = value
+		} else {
+			target.Cache.ScoreboardTTL = value
+		}
+	default:
+		return unknownKey(key)
+	}
+	return nil
+}
+
+// ProfileNames returns the names of the configured profiles, sorted.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// target splits "profiles.<name>.<key>" into the profile and key. With
+// create set, a missing profile is added.
+func (c *Config) target(key string, create bool) (*Config, string, error) {
+	if !strings.HasPrefix(key, "profiles.") {
+		return c, key, nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(key, "profiles."), ".", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, "", fmt.Errorf("profile keys look like profiles.<name>.<key>")
+	}
+	name, key := parts[0], parts[1]
+
+	p, ok := c.Profiles[name]
+	if !ok || p == nil {
+		if !create {
+			return nil, "", fmt.Errorf("unknown profile %q", name)
+		}
+		if c.Profiles == nil {
+			c.Profiles = map[string]*Config{}
+		}
+		p = &Config{}
+		c.Profiles[name] = p
+	}
+	return p, key, nil
+}
+
+func unknownKey(key string) error {
+	return fmt.Errorf("unknown config key %q (known keys: %s)", key, strings.Join(Keys, ", "))
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTTL parses a cache TTL setting, returning fallback when it is empty.
+// It rejects values that aren't durations, and negative ones.
+func ParseTTL(value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("must be a duration like 6h or 10s")
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("can't be negative")
+	}
+	return d, nil
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad_MissingFileIsEmpty(t *testing.T) {
+	c, err := Load(filepath.Join(t.TempDir(), "nope.yaml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.FavoriteTeam() != "" || !c.CacheEnabled() {
+		t.Fatalf("expected defaults, got %+v", c)
+	}
+}
+
+func TestSetSaveLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bball", "config.yaml")
+	c := &Config{}
+	for key, value := range map[string]string{
+		"favorite_teams":               "knicks, celtics",
+		"timezone":                     "America/Chicago",
+		"schedule_window":              "8",
+		"cache.enabled":                "false",
+		"cache.schedule_ttl":           "2h",
+		"profiles.west.timezone":       "America/Los_Angeles",
+		"profiles.west.favorite_teams": "lakers",
+	} {
+		if err := c.Set(key, value, nil); err != nil {
+			t.Fatalf("Set(%s): %v", key, err)
+		}
+	}
+	if err := c.Save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got, _ := loaded.Get("favorite_teams"); got != "knicks,celtics" {
+		t.Fatalf("unexpected favorite_teams %q", got)
+	}
+	if loaded.CacheEnabled() {
+		t.Fatalf("expected cache to be disabled")
+	}
+	if got, _ := loaded.Get("profiles.west.timezone"); got != "America/Los_Angeles" {
+		t.Fatalf("unexpected profile timezone %q", got)
+	}
+}
+
+func TestWithProfile_Overrides(t *testing.T) {
+	c := &Config{FavoriteTeams: []string{"knicks"}, Timezone: "America/New_York", ScheduleWindow: 5}
+	c.Set("profiles.west.favorite_teams", "lakers", nil)
+	c.Set("profiles.west.timezone", "America/Los_Angeles", nil)
+
+	west, err := c.WithProfile("west")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if west.FavoriteTeam() != "lakers" || west.Timezone != "America/Los_Angeles" || west.ScheduleWindow != 5 {
+		t.Fatalf("unexpected merged profile: %+v", west)
+	}
+
+	if _, err := c.WithProfile("east"); err == nil {
+		t.Fatalf("expected error for unknown profile")
+	}
+}
+
+func TestSet_Validation(t *testing.T) {
+	c := &Config{}
+	for key, value := range map[string]string{
+		"schedule_window":      "zero",
+		"cache.enabled":        "maybe",
+		"cache.schedule_ttl":   "soon",
+		"cache.scoreboard_ttl": "-10s",
+		"theme":                "neon",
+		"colour":               "red",
+	} {
+		if err := c.Set(key, value, nil); err == nil {
+			t.Errorf("Set(%s, %s) should fail", key, value)
+		}
+	}
+}
+
+func TestLoad_RejectsBadTTL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := "cache:\n  schedule_ttl: 6h\nprofiles:\n  work:\n    cache:\n      scoreboard_ttl: -5s\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Load(path)
+	if err == nil || !strings.Contains(err.Error(), "profiles.work.cache.scoreboard_ttl") {
+		t.Fatalf("expected the negative profile TTL to be reported, got %v", err)
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	if d, err := ParseTTL("", time.Minute); err != nil || d != time.Minute {
+		t.Fatalf("expected the fallback for an empty TTL, got %v %v", d, err)
+	}
+	if d, err := ParseTTL("30s", time.Minute); err != nil || d != 30*time.Second {
+		t.Fatalf("unexpected TTL %v %v", d, err)
+	}
+	for _, value := range []string{"soon", "-1m"} {
+		if _, err := ParseTTL(value, time.Minute); err == nil {
+			t.Errorf("ParseTTL(%q) should fail", value)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+// Package ics reads and writes iCalendar (RFC 5545) files of game events.
+package ics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProductID identifies bball as the calendar's producer.
+const ProductID = "-//internetdrew//bball//EN"
+
+// dateTimeLayout is the UTC form of an iCalendar DATE-TIME.
+const dateTimeLayout = "20060102T150405Z"
+
+// dateLayout is an iCalendar DATE.
+const dateLayout = "20060102"
+
+// maxLineOctets is where content lines are folded.
+const maxLineOctets = 75
+
+// Event is a VEVENT.
+type Event struct {
+	UID          string
+	Summary      string
+	Location     string
+	Description  string
+	Status       string // "CONFIRMED", "TENTATIVE" or "CANCELLED"
+	Start        time.Time
+	End          time.Time // exclusive; the next day for a one-day AllDay event
+	AllDay       bool      // Start and End are dates, written as VALUE=DATE
+	Sequence     int
+	Stamp        time.Time // DTSTAMP
+	LastModified time.Time
+}
+
+// sameContent reports whether e and other would show the same thing in a
+// calendar, ignoring the bookkeeping fields.
+func (e Event) sameContent(other Event) bool {
+	return e.Summary == other.Summary &&
+		e.Location == other.Location &&
+		e.Description == other.Description &&
+		e.Status == other.Status &&
+		e.AllDay == other.AllDay &&
+		e.Start.Equal(other.Start) &&
+		e.End.Equal(other.End)
+}
+
+// Calendar is a VCALENDAR.
+type Calendar struct {
+	Name   string // X-WR-CALNAME, shown by most calendar apps
+	Events []Event
+}
+
+// Changes counts what Merge did.
+type Changes struct {
+	Added   int
+	Updated int
+}
+
+// Merge returns previous with the events of next added or updated in place,
+// keyed by UID. An event whose content changed gets a higher SEQUENCE so
+// calendar apps replace their copy instead of keeping the old one; an
+// unchanged event keeps its sequence and modification time. Events only in
+// previous are kept. Events are sorted by start time.
+func Merge(previous, next *Calendar, now time.Time) (*Calendar, Changes) {
+	now = now.UTC().Truncate(time.Second)
+
+	merged := &Calendar{Name: next.Name}
+	if merged.Name == "" && previous != nil {
+		merged.Name = previous.Name
+	}
+
+	index := map[string]int{}
+	if previous != nil {
+		for _, e := range previous.Events {
+			index[e.UID] = len(merged.Events)
+			merged.Events = append(merged.Events, e)
+		}
+	}
+
+	var changes Changes
+	for _, e := range next.Events {
+		e.Stamp = now
+		i, ok := index[e.UID]
+		switch {
+		case !ok:
+			e.LastModified = now
+			index[e.UID] = len(merged.Events)
+			merged.Events = append(merged.Events, e)
+			changes.Added++
+			continue
+		case e.sameContent(merged.Events[i]):
+			e.Sequence = merged.Events[i].Sequence
+			e.LastModified = merged.Events[i].LastModified
+		default:
+			e.Sequence = merged.Events[i].Sequence + 1
+			e.LastModified = now
+			changes.Updated++
+		}
+		merged.Events[i] = e
+	}
+
+	sort.SliceStable(merged.Events, func(i, j int) bool {
+		return merged.Events[i].Start.Before(merged.Events[j].Start)
+	})
+	return merged, changes
+}
+
+// UpdateFile merges c into the calendar file at path, creating it if it
+// doesn't exist, and replaces the file atomically.
+func UpdateFile(path string, c *Calendar, now time.Time) (Changes, error) {
+	var previous *Calendar
+	f, err := os.Open(path)
+	switch {
+	case err == nil:
+		previous, err = Parse(f)
+		f.Close()
+		if err != nil {
+			return Changes{}, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return Changes{}, err
+	}
+
+	merged, changes := Merge(previous, c, now)
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".bball-*.ics")
+	if err != nil {
+		return Changes{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := Write(tmp, merged); err != nil {
+		tmp.Close()
+		return Changes{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return Changes{}, err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return Changes{}, err
+	}
+	return changes, os.Rename(tmp.Name(), path)
+}
+
+// Write encodes c as an iCalendar file with CRLF line endings and lines
+// folded at 75 octets.
+func Write(w io.Writer, c *Calendar) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		bw.WriteString(fold(name+":"+value) + "\r\n")
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", ProductID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escapeText(c.Name))
+	}
+
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", e.UID)
+		line("DTSTAMP", formatTime(e.Stamp))
+		if e.AllDay {
+			line("DTSTART;VALUE=DATE", e.Start.Format(dateLayout))
+			line("DTEND;VALUE=DATE", e.End.Format(dateLayout))
+		} else {
+			line("DTSTART", formatTime(e.Start))
+			line("DTEND", formatTime(e.End))
+		}
+		line("SEQUENCE", strconv.Itoa(e.Sequence))
+		if !e.LastModified.IsZero() {
+			line("LAST-MODIFIED", formatTime(e.LastModified))
+		}
+		line("SUMMARY", escapeText(e.Summary))
+		if e.Location != "" {
+			line("LOCATION", escapeText(e.Location))
+		}
+		if e.Description != "" {
+			line("DESCRIPTION", escapeText(e.Description))
+		}
+		if e.Status != "" {
+			line("STATUS", e.Status)
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// Parse reads the events of an iCalendar file. It understands the
+// properties Write produces and ignores everything else, so a file edited
+// by hand or by another tool still merges.
+func Parse(r io.Reader) (*Calendar, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Calendar{}
+	var event *Event
+	nested := 0 // depth of components such as VALARM inside the event
+	for n, raw := range lines {
+		name, value, ok := splitProperty(raw)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case event != nil && name == "BEGIN":
+			nested++
+		case nested > 0:
+			if name == "END" {
+				nested--
+			}
+		case name == "BEGIN" && value == "VEVENT":
+			event = &Event{}
+		case name == "END" && value == "VEVENT":
+			if event == nil {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN", n+1)
+			}
+			if event.UID != "" {
+				c.Events = append(c.Events, *event)
+			}
+			event = nil
+		case event == nil:
+			if name == "X-WR-CALNAME" {
+				c.Name = unescapeText(value)
+			}
+		default:
+			if err := event.set(name, value); err != nil {
+				return nil, fmt.Errorf("line %d: %w", n+1, err)
+			}
+		}
+	}
+	if event != nil {
+		return nil, fmt.Errorf("unterminated VEVENT")
+	}
+	return c, nil
+}
+
+func (e *Event) set(name, value string) error {
+	var err error
+	switch name {
+	case "UID":
+		e.UID = value
+	case "SUMMARY":
+		e.Summary = unescapeText(value)
+	case "LOCATION":
+		e.Location = unescapeText(value)
+	case "DESCRIPTION":
+		e.Description = unescapeText(value)
+	case "STATUS":
+		e.Status = value
+	case "SEQUENCE":
+		e.Sequence, err = strconv.Atoi(value)
+	case "DTSTART":
+		e.Start, err = parseTime(value)
+		e.AllDay = len(value) == len(dateLayout)
+	case "DTEND":
+		e.End, err = parseTime(value)
+	case "DTSTAMP":
+		e.Stamp, err = parseTime(value)
+	case "LAST-MODIFIED":
+		e.LastModified, err = parseTime(value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", name, value)
+	}
+	return nil
+}
+
+// splitProperty splits a content line into its name and value, dropping
+// any parameters such as ";TZID=...".
+func splitProperty(line string) (name, value string, ok bool) {
+	colon := strings.IndexByte(line, ':')
+	if colon < 0 {
+		return "", "", false
+	}
+	name = line[:colon]
+	if semi := strings.IndexByte(name, ';'); semi >= 0 {
+		name = name[:semi]
+	}
+	return strings.ToUpper(name), line[colon+1:], true
+}
+
+// unfold joins continuation lines, which start with a space or tab.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// fold splits line into chunks of at most 75 octets without breaking a
+// UTF-8 sequence, as RFC 5545 section 3.1 requires.
+func fold(line string) string {
+	if len(line) <= maxLineOctets {
+		return line
+	}
+
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1 // the leading space counts
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xc0 != 0x80
+}
+
+var (
+	textEscaper   = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+)
+
+func escapeText(s string) string   { return textEscaper.Replace(s) }
+func unescapeText(s string) string { return textUnescaper.Replace(s) }
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+// parseTime reads a UTC or floating DATE-TIME, or a DATE.
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range []string{dateTimeLayout, "20060102T150405", dateLayout} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date-time %q", s)
+}
@@ -0,0 +1,160 @@
+package ics
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var tipOff = time.Date(2024, 10, 22, 23, 30, 0, 0, time.UTC)
+
+func testEvent(uid string, start time.Time) Event {
+	return Event{
+		UID:         uid,
+		Summary:     "Knicks @ Celtics",
+		Location:    "TD Garden, Boston, MA",
+		Description: "TV: TNT\nGame ID: 0022400061",
+		Status:      "CONFIRMED",
+		Start:       start,
+		End:         start.Add(GameDuration),
+	}
+}
+
+func TestWrite(t *testing.T) {
+	c := &Calendar{Name: "Celtics", Events: []Event{testEvent("0022400061@bball", tipOff)}}
+	c.Events[0].Stamp = tipOff
+
+	var buf bytes.Buffer
+	if err := Write(&buf, c); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"UID:0022400061@bball\r\n",
+		"DTSTART:20241022T233000Z\r\n",
+		"DTEND:20241023T020000Z\r\n",
+		"LOCATION:TD Garden\\, Boston\\, MA\r\n",
+		"DESCRIPTION:TV: TNT\\nGame ID: 0022400061\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("é", 60)
+	folded := fold(line)
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > maxLineOctets {
+			t.Fatalf("line of %d octets: %q", len(part), part)
+		}
+	}
+	lines, err := unfold(strings.NewReader(folded))
+	if err != nil || len(lines) != 1 || lines[0] != line {
+		t.Fatalf("unfold(fold(line)) = %q, %v", lines, err)
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	want := testEvent("0022400061@bball", tipOff)
+	want.Sequence, want.Stamp, want.LastModified = 2, tipOff, tipOff
+
+	var buf bytes.Buffer
+	if err := Write(&buf, &Calendar{Name: "Celtics, 2024-25", Events: []Event{want}}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "Celtics, 2024-25" || len(c.Events) != 1 {
+		t.Fatalf("unexpected calendar %+v", c)
+	}
+	got := c.Events[0]
+	if !got.sameContent(want) || got.Sequence != 2 || !got.LastModified.Equal(tipOff) {
+		t.Fatalf("round trip changed the event:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseIgnoresAlarms(t *testing.T) {
+	src := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:1@bball\r\nDESCRIPTION:game\r\n" +
+		"BEGIN:VALARM\r\nDESCRIPTION:reminder\r\nEND:VALARM\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	c, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Events) != 1 || c.Events[0].Description != "game" {
+		t.Fatalf("unexpected events %+v", c.Events)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	earlier := tipOff.Add(-time.Hour)
+	previous := &Calendar{Events: []Event{
+		testEvent("a", tipOff),
+		testEvent("b", tipOff.Add(24*time.Hour)),
+		testEvent("other", tipOff.Add(48*time.Hour)),
+	}}
+	previous.Events[0].LastModified = earlier
+
+	next := &Calendar{Name: "Celtics", Events: []Event{
+		testEvent("a", tipOff),                   // unchanged
+		testEvent("b", tipOff.Add(25*time.Hour)), // rescheduled
+		testEvent("c", tipOff.Add(72*time.Hour)), // new
+	}}
+
+	now := tipOff.Add(time.Minute)
+	merged, changes := Merge(previous, next, now)
+
+	if changes != (Changes{Added: 1, Updated: 1}) {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if len(merged.Events) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(merged.Events))
+	}
+	byUID := map[string]Event{}
+	for _, e := range merged.Events {
+		byUID[e.UID] = e
+	}
+	if a := byUID["a"]; a.Sequence != 0 || !a.LastModified.Equal(earlier) {
+		t.Errorf("unchanged event was touched: %+v", a)
+	}
+	if b := byUID["b"]; b.Sequence != 1 || !b.LastModified.Equal(now) || !b.Start.Equal(tipOff.Add(25*time.Hour)) {
+		t.Errorf("rescheduled event not updated: %+v", b)
+	}
+	if _, ok := byUID["other"]; !ok {
+		t.Error("events not in the new export should be kept")
+	}
+}
+
+func TestUpdateFile_DoesNotDuplicate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "celtics.ics")
+	c := &Calendar{Name: "Celtics", Events: []Event{testEvent("a@bball", tipOff)}}
+
+	if changes, err := UpdateFile(path, c, tipOff); err != nil || changes.Added != 1 {
+		t.Fatalf("first export: %+v, %v", changes, err)
+	}
+
+	c.Events[0].Start = tipOff.Add(2 * time.Hour)
+	if changes, err := UpdateFile(path, c, tipOff.Add(time.Hour)); err != nil || changes.Updated != 1 {
+		t.Fatalf("second export: %+v, %v", changes, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "BEGIN:VEVENT"); n != 1 {
+		t.Fatalf("expected 1 event after re-export, got %d", n)
+	}
+	if !strings.Contains(string(data), "SEQUENCE:1\r\n") || !strings.Contains(string(data), "DTSTART:20241023T013000Z") {
+		t.Fatalf("event was not updated:\n%s", data)
+	}
+}
@@ -0,0 +1,146 @@
+package ics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/util"
+)
+
+// GameDuration is how long a game event lasts. Regulation plus breaks
+// usually runs about two and a half hours.
+const GameDuration = 2*time.Hour + 30*time.Minute
+
+// uidSuffix keeps game UIDs apart from other producers' events.
+const uidSuffix = "@bball"
+
+// GameUID returns the stable UID of a game's event. It depends only on the
+// game ID, so a rescheduled game updates the same event.
+func GameUID(game nba.Game) string {
+	return game.ID + uidSuffix
+}
+
+// GameEvent converts a schedule game to an event. A game whose tip-off
+// time hasn't been set is an all-day event on its date, rather than a timed
+// one at the feed's placeholder time. It reports false when the game has
+// neither a start time nor a date.
+func GameEvent(game nba.Game) (Event, bool) {
+	if game.ID == "" {
+		return Event{}, false
+	}
+	e := Event{
+		UID:         GameUID(game),
+		Summary:     gameSummary(game),
+		Location:    gameLocation(game),
+		Description: gameDescription(game),
+		Status:      gameStatus(game),
+	}
+
+	if game.State().IsTimeTBD() {
+		day, ok := gameDay(game)
+		if !ok {
+			return Event{}, false
+		}
+		e.AllDay, e.Start, e.End = true, day, day.AddDate(0, 0, 1)
+		return e, true
+	}
+
+	start, err := time.Parse(time.RFC3339, game.StartTimeUTC())
+	if err != nil {
+		return Event{}, false
+	}
+	e.Start, e.End = start.UTC(), start.UTC().Add(GameDuration)
+	return e, true
+}
+
+// gameDay is the date a game without a tip-off time is scheduled for:
+// gameDateTimeEst's date, or failing that the placeholder start time's date
+// at the arena.
+func gameDay(game nba.Game) (time.Time, bool) {
+	if len(game.GameDateTimeEst) >= len("2006-01-02") {
+		if day, err := time.Parse("2006-01-02", game.GameDateTimeEst[:len("2006-01-02")]); err == nil {
+			return day, true
+		}
+	}
+	start, err := time.Parse(time.RFC3339, game.StartTimeUTC())
+	if err != nil {
+		return time.Time{}, false
+	}
+	if loc := game.ArenaLocation(); loc != nil {
+		start = start.In(loc)
+	}
+	return time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC), true
+}
+
+// GameCalendar builds a calendar named name from games, skipping any
+// without a start time.
+func GameCalendar(name string, games []nba.Game) *Calendar {
+	c := &Calendar{Name: name}
+	for _, g := range games {
+		if e, ok := GameEvent(g); ok {
+			c.Events = append(c.Events, e)
+		}
+	}
+	return c
+}
+
+// gameSummary is the event title, like "Knicks @ Celtics", with the score
+// once the game is final.
+func gameSummary(game nba.Game) string {
+	away, home := teamName(game.AwayTeam), teamName(game.HomeTeam)
+	if game.State().IsFinal() {
+		return fmt.Sprintf("%s %d @ %s %d", away, game.AwayTeam.Score, home, game.HomeTeam.Score)
+	}
+	return fmt.Sprintf("%s @ %s", away, home)
+}
+
+func teamName(team nba.Team) string {
+	if team.Name != "" {
+		return team.Name
+	}
+	return team.Tricode
+}
+
+// gameLocation is the arena and its city, like "TD Garden, Boston, MA".
+func gameLocation(game nba.Game) string {
+	var parts []string
+	for _, p := range []string{game.ArenaName, game.ArenaCity, game.ArenaState} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func gameDescription(game nba.Game) string {
+	var lines []string
+	switch state := game.State(); {
+	case state.IsFinal():
+		lines = append(lines, fmt.Sprintf("Final: %s %d, %s %d",
+			game.AwayTeam.Tricode, game.AwayTeam.Score, game.HomeTeam.Tricode, game.HomeTeam.Score))
+	case state.IsOff():
+		lines = append(lines, state.String())
+	case state.IsTimeTBD():
+		lines = append(lines, "Tip-off time TBD")
+	}
+	if tv := util.FormatBroadcasters(game.Broadcasters); tv != "" {
+		lines = append(lines, "TV: "+tv)
+	}
+	lines = append(lines, "Game ID: "+game.ID)
+	return strings.Join(lines, "\n")
+}
+
+// gameStatus is the event's STATUS. A postponed game is TENTATIVE until
+// it's made up, which moves the same event since GameUID only depends on
+// the game ID; only a cancelled game is CANCELLED.
+func gameStatus(game nba.Game) string {
+	switch game.State() {
+	case nba.Postponed:
+		return "TENTATIVE"
+	case nba.Cancelled:
+		return "CANCELLED"
+	}
+	return "CONFIRMED"
+}
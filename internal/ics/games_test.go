@@ -0,0 +1,136 @@
+package ics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestGameEvent(t *testing.T) {
+	game := nba.Game{
+		ID:              "0022400061",
+		GameStatus:      1,
+		GameTimeUTC:     "1900-01-01T23:30:00Z",
+		GameDateTimeUTC: "2024-10-22T23:30:00Z",
+		HomeTeam:        nba.Team{Name: "Celtics", Tricode: "BOS"},
+		AwayTeam:        nba.Team{Name: "Knicks", Tricode: "NYK"},
+		ArenaName:       "TD Garden",
+		ArenaCity:       "Boston",
+		ArenaState:      "MA",
+		Broadcasters: nba.Broadcasters{
+			National: []nba.Broadcaster{{Display: "TNT"}},
+		},
+	}
+
+	e, ok := GameEvent(game)
+	if !ok {
+		t.Fatal("expected an event")
+	}
+	if e.UID != "0022400061@bball" || e.Summary != "Knicks @ Celtics" || e.Status != "CONFIRMED" {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	if !e.Start.Equal(time.Date(2024, 10, 22, 23, 30, 0, 0, time.UTC)) || e.End.Sub(e.Start) != GameDuration {
+		t.Fatalf("unexpected times %s - %s", e.Start, e.End)
+	}
+	if e.Location != "TD Garden, Boston, MA" || e.Description != "TV: TNT\nGame ID: 0022400061" {
+		t.Fatalf("unexpected location or description: %q, %q", e.Location, e.Description)
+	}
+}
+
+func TestGameEvent_FinalAndPostponed(t *testing.T) {
+	final := nba.Game{
+		ID: "1", GameStatus: 3, GameDateTimeUTC: "2024-10-22T23:30:00Z",
+		HomeTeam: nba.Team{Name: "Celtics", Tricode: "BOS", Score: 132},
+		AwayTeam: nba.Team{Name: "Knicks", Tricode: "NYK", Score: 109},
+	}
+	if e, _ := GameEvent(final); e.Summary != "Knicks 109 @ Celtics 132" {
+		t.Fatalf("unexpected summary %q", e.Summary)
+	}
+
+	ppd := nba.Game{ID: "2", GameStatus: 1, GameStatusText: "PPD", GameDateTimeUTC: "2024-10-22T23:30:00Z"}
+	if e, _ := GameEvent(ppd); e.Status != "TENTATIVE" || e.Description != "Postponed\nGame ID: 2" {
+		t.Fatalf("postponed game should be tentative, got %q %q", e.Status, e.Description)
+	}
+
+	cancelled := nba.Game{ID: "4", GameStatus: 1, GameStatusText: "Cancelled", GameDateTimeUTC: "2024-10-22T23:30:00Z"}
+	if e, _ := GameEvent(cancelled); e.Status != "CANCELLED" {
+		t.Fatalf("cancelled game should be cancelled, got %q", e.Status)
+	}
+
+	if _, ok := GameEvent(nba.Game{ID: "3"}); ok {
+		t.Fatal("a game without a start time should be skipped")
+	}
+}
+
+func TestGameEvent_TimeTBDIsAllDay(t *testing.T) {
+	game := nba.Game{
+		ID: "0042300405", GameStatus: 1, GameStatusText: "TBD",
+		GameDateTimeUTC: "2024-06-17T04:00:00Z", GameDateTimeEst: "2024-06-17T00:00:00Z",
+		HomeTeam: nba.Team{Name: "Celtics", Tricode: "BOS"},
+		AwayTeam: nba.Team{Name: "Mavericks", Tricode: "DAL"},
+	}
+
+	e, ok := GameEvent(game)
+	if !ok {
+		t.Fatal("expected an event")
+	}
+	if !e.AllDay || !e.Start.Equal(time.Date(2024, 6, 17, 0, 0, 0, 0, time.UTC)) || !e.End.Equal(time.Date(2024, 6, 18, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected an all-day event on Jun 17, got %+v", e)
+	}
+	if e.Description != "Tip-off time TBD\nGame ID: 0042300405" {
+		t.Fatalf("unexpected description %q", e.Description)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, &Calendar{Events: []Event{e}}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "DTSTART;VALUE=DATE:20240617\r\n") || !strings.Contains(buf.String(), "DTEND;VALUE=DATE:20240618\r\n") {
+		t.Fatalf("expected DATE values:\n%s", buf.String())
+	}
+	parsed, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parsed.Events[0]; !got.AllDay || !got.sameContent(e) {
+		t.Fatalf("all-day event didn't round-trip: %+v", got)
+	}
+
+	game.GameStatusText, game.GameDateTimeUTC = "", "2024-06-17T00:30:00Z"
+	if e, _ := GameEvent(game); e.AllDay {
+		t.Fatal("a game with a tip-off time should be timed")
+	}
+}
+
+func TestMerge_PostponedAndMadeUp(t *testing.T) {
+	game := nba.Game{ID: "0022400061", GameStatus: 1, GameDateTimeUTC: "2024-10-22T23:30:00Z",
+		HomeTeam: nba.Team{Name: "Celtics"}, AwayTeam: nba.Team{Name: "Knicks"}}
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	export := func(previous *Calendar, g nba.Game) *Calendar {
+		merged, _ := Merge(previous, GameCalendar("Celtics", []nba.Game{g}), now)
+		now = now.Add(24 * time.Hour)
+		return merged
+	}
+
+	c := export(nil, game)
+
+	ppd := game
+	ppd.GameStatusText = "PPD"
+	c = export(c, ppd)
+	if e := c.Events[0]; e.Status != "TENTATIVE" || e.Sequence != 1 {
+		t.Fatalf("postponed: %+v", e)
+	}
+
+	madeUp := game
+	madeUp.GameDateTimeUTC = "2025-03-04T00:00:00Z"
+	c = export(c, madeUp)
+	if len(c.Events) != 1 {
+		t.Fatalf("the made-up game should update the same event, got %d events", len(c.Events))
+	}
+	if e := c.Events[0]; e.Status != "CONFIRMED" || e.Sequence != 2 || !e.Start.Equal(time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("made up: %+v", e)
+	}
+}
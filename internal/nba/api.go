@@ -1,62 +1,118 @@
 package nba
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
-	"fmt"
-	"net/http"
-	"strings"
 	"time"
 )
 
+// Default endpoints used by NewClient and the package-level helpers.
 var (
 	ScoreboardURL     = "https://cdn.nba.com/static/json/liveData/scoreboard/todaysScoreboard_00.json"
 	LeagueScheduleURL = "https://cdn.nba.com/static/json/staticData/scheduleLeagueV2.json"
 )
 
-// ErrTeamNotFound indicates no current game for the provided team was found
-// in today's scoreboard data.
+// ErrTeamNotFound indicates the team has no game where one was looked for:
+// in today's scoreboard, or anywhere in the league schedule.
 var ErrTeamNotFound = errors.New("team not found")
 
 type Scoreboard struct {
 	Scoreboard struct {
-		Games []Game `json:"games"`
+		GameDate string `json:"gameDate"` // e.g. "2024-11-01", in US Eastern time
+		Games    []Game `json:"games"`
 	} `json:"scoreboard"`
 	Meta struct {
-		Time string `json:"time"`
+		Time string `json:"time"` // e.g. "2024-11-01 20:15:00.000", in UTC
 	} `json:"meta"`
 }
 
-func FetchScoreboard() (*Scoreboard, error) {
-	res, err := http.Get(ScoreboardURL)
-	if err != nil {
-		return nil, err
+// metaTimeLayouts are the formats seen in the liveData feeds' meta.time.
+var metaTimeLayouts = []string{"2006-01-02 15:04:05.999999999", time.RFC3339Nano}
+
+// UpdatedAt returns when the CDN last wrote the scoreboard, and false when
+// the feed doesn't say.
+func (s *Scoreboard) UpdatedAt() (time.Time, bool) {
+	for _, layout := range metaTimeLayouts {
+		if t, err := time.Parse(layout, s.Meta.Time); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// IsFor reports whether the scoreboard holds day's games. The CDN rolls it
+// over to the next day mid-morning US Eastern time, so after midnight it
+// still has the night before's. A scoreboard without a date is taken to be
+// current.
+func (s *Scoreboard) IsFor(day time.Time) bool {
+	return s.Scoreboard.GameDate == "" || s.Scoreboard.GameDate == day.Format("2006-01-02")
+}
+
+// TeamGame returns franchise's game in the scoreboard, whatever its status.
+func (s *Scoreboard) TeamGame(franchise *Franchise) (Game, bool) {
+	for _, g := range s.Scoreboard.Games {
+		if gameInvolves(g, franchise) {
+			return g, true
+		}
 	}
-	defer res.Body.Close()
+	return Game{}, false
+}
+
+// LeagueScheduleResponse represents the full season schedule
+type LeagueScheduleResponse struct {
+	LeagueSchedule struct {
+		GameDates []struct {
+			GameDate string `json:"gameDate"`
+			Games    []Game `json:"games"`
+		} `json:"gameDates"`
+	} `json:"leagueSchedule"`
+}
+
+// FetchScoreboard fetches today's scoreboard using the default endpoints.
+func FetchScoreboard() (*Scoreboard, error) {
+	return NewClient().FetchScoreboard(context.Background())
+}
+
+// FindTeamGame returns the live game for team using the default endpoints.
+func FindTeamGame(team string) (*Game, error) {
+	return NewClient().FindTeamGame(context.Background(), team)
+}
+
+// FetchTeamSchedule returns a team's upcoming or recent games using the
+// default endpoints.
+func FetchTeamSchedule(teamQuery string, mode string) ([]Game, error) {
+	return NewClient().FetchTeamSchedule(context.Background(), teamQuery, mode)
+}
 
+// FetchScoreboard fetches today's scoreboard.
+func (c *Client) FetchScoreboard(ctx context.Context) (*Scoreboard, error) {
 	var data Scoreboard
-	if err := json.NewDecoder(res.Body).Decode(&data); err != nil {
+	if err := c.fetchJSON(ctx, c.ScoreboardURL, c.ScoreboardTTL, "scoreboard", &data); err != nil {
 		return nil, err
 	}
 
 	return &data, nil
 }
 
-func FindTeamGame(team string) (*Game, error) {
-	board, err := FetchScoreboard()
+// FindTeamGame returns the live game in today's scoreboard involving team.
+// team is resolved with Resolve.
+func (c *Client) FindTeamGame(ctx context.Context, team string) (*Game, error) {
+	franchise, err := Resolve(team)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := c.FetchScoreboard(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, g := range board.Scoreboard.Games {
 		// Only consider live games for "catch"
-		if g.GameStatus != 2 { // 1=Scheduled, 2=Live, 3=Final
+		if !g.State().IsLive() {
 			continue
 		}
-		if strings.Contains(strings.ToLower(g.HomeTeam.Name), team) ||
-			strings.Contains(strings.ToLower(g.AwayTeam.Name), team) ||
-			strings.EqualFold(g.HomeTeam.Tricode, team) ||
-			strings.EqualFold(g.AwayTeam.Tricode, team) {
+		if gameInvolves(g, franchise) {
 			return &g, nil
 		}
 	}
@@ -64,88 +120,130 @@ func FindTeamGame(team string) (*Game, error) {
 	return nil, ErrTeamNotFound
 }
 
-// LeagueScheduleResponse represents the full season schedule
-type LeagueScheduleResponse struct {
-	LeagueSchedule struct {
-		GameDates []struct {
-			GameDate string `json:"gameDate"`
-			Games    []Game `json:"games"`
-		} `json:"gameDates"`
-	} `json:"leagueSchedule"`
-}
+// FindTodaysTeamGame returns team's game in today's scoreboard whatever its
+// status. team is resolved with Resolve.
+func (c *Client) FindTodaysTeamGame(ctx context.Context, team string) (*Game, error) {
+	franchise, err := Resolve(team)
+	if err != nil {
+		return nil, err
+	}
 
-func FetchTeamSchedule(teamQuery string, mode string) ([]Game, error) {
-	// Fetch the full league schedule
-	resp, err := http.Get(LeagueScheduleURL)
+	board, err := c.FetchScoreboard(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch schedule: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("schedule not available (status: %d)", resp.StatusCode)
+	if g, ok := board.TeamGame(franchise); ok {
+		return &g, nil
 	}
+	return nil, ErrTeamNotFound
+}
+
+// gameInvolves reports whether franchise plays in g.
+func gameInvolves(g Game, franchise *Franchise) bool {
+	return franchise.Matches(g.HomeTeam) || franchise.Matches(g.AwayTeam)
+}
 
+// FetchLeagueSchedule fetches the full season schedule.
+func (c *Client) FetchLeagueSchedule(ctx context.Context) (*LeagueScheduleResponse, error) {
 	var scheduleResp LeagueScheduleResponse
-	if err := json.NewDecoder(resp.Body).Decode(&scheduleResp); err != nil {
-		return nil, fmt.Errorf("failed to parse schedule: %w", err)
+	if err := c.fetchJSON(ctx, c.LeagueScheduleURL, c.ScheduleTTL, "schedule", &scheduleResp); err != nil {
+		return nil, err
 	}
 
+	return &scheduleResp, nil
+}
+
+// FetchTeamSchedule returns up to ScheduleWindow upcoming or recent games for
+// a team, or every game of its season.
+// teamQuery is resolved with Resolve; mode is "upcoming", "recent" or "season".
+func (c *Client) FetchTeamSchedule(ctx context.Context, teamQuery string, mode string) ([]Game, error) {
+	franchise, err := Resolve(teamQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch the full league schedule
+	scheduleResp, err := c.FetchLeagueSchedule(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduleResp.TeamSchedule(franchise, mode, c.ScheduleWindow, time.Now())
+}
+
+// Game returns the game with id from the schedule.
+func (s *LeagueScheduleResponse) Game(id string) (Game, bool) {
+	for _, gameDate := range s.LeagueSchedule.GameDates {
+		for _, g := range gameDate.Games {
+			if g.ID == id {
+				return g, true
+			}
+		}
+	}
+	return Game{}, false
+}
+
+// TeamSchedule is FetchTeamSchedule for a schedule that has already been
+// fetched. window limits the upcoming and recent modes (DefaultScheduleWindow
+// when not positive), and upcoming games are those after now.
+func (s *LeagueScheduleResponse) TeamSchedule(franchise *Franchise, mode string, window int, now time.Time) ([]Game, error) {
 	// Flatten all games and filter by team
 	var teamGames []Game
-	teamLower := strings.ToLower(teamQuery)
 
-	for _, gameDate := range scheduleResp.LeagueSchedule.GameDates {
+	for _, gameDate := range s.LeagueSchedule.GameDates {
 		for _, game := range gameDate.Games {
 			// Check if this game involves the requested team
-			if strings.Contains(strings.ToLower(game.HomeTeam.Name), teamLower) ||
-				strings.Contains(strings.ToLower(game.AwayTeam.Name), teamLower) ||
-				strings.EqualFold(game.HomeTeam.Tricode, teamQuery) ||
-				strings.EqualFold(game.AwayTeam.Tricode, teamQuery) {
+			if gameInvolves(game, franchise) {
 				teamGames = append(teamGames, game)
 			}
 		}
 	}
 
 	if len(teamGames) == 0 {
-		return nil, errors.New("team not found")
+		return nil, ErrTeamNotFound
 	}
 
 	// Filter based on mode
-	now := time.Now()
 	var filtered []Game
 
+	if window <= 0 {
+		window = DefaultScheduleWindow
+	}
+
 	switch mode {
 	case "upcoming":
-		// Get next 5 scheduled games (status = 1 for scheduled)
+		// The next games still to be played. Games without a tip-off time
+		// and postponed games are kept so they show up as such; their
+		// listed time is only a date, so anything from the last day counts.
 		count := 0
 		for _, game := range teamGames {
-			if game.GameStatus == 1 && count < 5 {
-				// Parse the game date/time using gameDateTimeUTC
-				var gameTime time.Time
-				var err error
-
-				if game.GameDateTimeUTC != "" {
-					gameTime, err = time.Parse(time.RFC3339, game.GameDateTimeUTC)
-				}
-
-				if err == nil && !gameTime.IsZero() && gameTime.After(now) {
-					filtered = append(filtered, game)
-					count++
-				}
+			state := game.State()
+			if count >= window || !(state.IsUpcoming() || state.IsPostponed()) {
+				continue
+			}
+
+			gameTime, err := time.Parse(time.RFC3339, game.StartTimeUTC())
+			switch {
+			case state == Scheduled && gameTime.After(now),
+				state != Scheduled && (err != nil || gameTime.After(now.Add(-24*time.Hour))):
+				filtered = append(filtered, game)
+				count++
 			}
 		}
 	case "recent":
 
 		for _, game := range teamGames {
-			if game.GameStatus == 3 {
+			if game.State().IsFinal() {
 				filtered = append(filtered, game)
 			}
 		}
 
-		if len(filtered) > 5 {
-			filtered = filtered[len(filtered)-5:]
+		if len(filtered) > window {
+			filtered = filtered[len(filtered)-window:]
 		}
+	case "season":
+		filtered = teamGames
 	}
 
 	return filtered, nil
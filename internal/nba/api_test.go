@@ -1,6 +1,7 @@
 package nba_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -77,6 +78,9 @@ func TestFetchTeamSchedule_Upcoming(t *testing.T) {
 		{HomeTeam: nba.Team{Name: "Boston Celtics", Tricode: "BOS"}, AwayTeam: nba.Team{Name: "New York Knicks", Tricode: "NYK"}, GameStatus: 1, GameDateTimeUTC: now.Add(2 * time.Hour).Format(time.RFC3339)},
 		{HomeTeam: nba.Team{Name: "Miami Heat", Tricode: "MIA"}, AwayTeam: nba.Team{Name: "Boston Celtics", Tricode: "BOS"}, GameStatus: 1, GameDateTimeUTC: now.Add(4 * time.Hour).Format(time.RFC3339)},
 		{HomeTeam: nba.Team{Name: "Chicago Bulls", Tricode: "CHI"}, AwayTeam: nba.Team{Name: "Boston Celtics", Tricode: "BOS"}, GameStatus: 3}, // completed, should be ignored in upcoming
+		{HomeTeam: nba.Team{Name: "Boston Celtics", Tricode: "BOS"}, AwayTeam: nba.Team{Name: "Orlando Magic", Tricode: "ORL"}, GameStatus: 1, GameStatusText: "PPD", GameDateTimeUTC: now.Add(24 * time.Hour).Format(time.RFC3339)},
+		{HomeTeam: nba.Team{Name: "Boston Celtics", Tricode: "BOS"}, AwayTeam: nba.Team{Name: "Utah Jazz", Tricode: "UTA"}, GameStatus: 1, GameStatusText: "TBD", GameDateTimeUTC: now.Add(48 * time.Hour).Format(time.RFC3339)},
+		{HomeTeam: nba.Team{Name: "Boston Celtics", Tricode: "BOS"}, AwayTeam: nba.Team{Name: "Denver Nuggets", Tricode: "DEN"}, GameStatus: 1, GameDateTimeUTC: now.Add(-72 * time.Hour).Format(time.RFC3339)}, // in the past
 	}
 	payload := struct {
 		LeagueSchedule struct {
@@ -106,8 +110,8 @@ func TestFetchTeamSchedule_Upcoming(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(result) != 2 {
-		t.Fatalf("expected 2 upcoming games, got %d", len(result))
+	if len(result) != 4 {
+		t.Fatalf("expected 4 upcoming games with the postponed and TBD ones, got %d", len(result))
 	}
 }
 
@@ -194,3 +198,68 @@ func TestFetchTeamSchedule_HTTPError(t *testing.T) {
 		t.Fatalf("expected error on non-200 response, got nil")
 	}
 }
+
+func TestFetchTeamSchedule_Season(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"leagueSchedule":{"gameDates":[
+			{"gameDate":"10/22/2024 00:00:00","games":[
+				{"gameId":"0022400061","gameStatus":3,"homeTeam":{"teamTricode":"BOS"},"awayTeam":{"teamTricode":"NYK"}},
+				{"gameId":"0022400062","gameStatus":3,"homeTeam":{"teamTricode":"LAL"},"awayTeam":{"teamTricode":"MIN"}}]},
+			{"gameDate":"04/13/2025 00:00:00","games":[
+				{"gameId":"0022401200","gameStatus":1,"homeTeam":{"teamTricode":"CHA"},"awayTeam":{"teamTricode":"BOS"}}]}]}}`))
+	}))
+	defer server.Close()
+
+	c := nba.NewClient()
+	c.LeagueScheduleURL = server.URL
+	c.ScheduleWindow = 1
+
+	games, err := c.FetchTeamSchedule(context.Background(), "celtics", "season")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(games) != 2 || games[0].ID != "0022400061" || games[1].ID != "0022401200" {
+		t.Fatalf("expected both Celtics games regardless of window, got %+v", games)
+	}
+}
+
+func TestScoreboard_UpdatedAt(t *testing.T) {
+	var board nba.Scoreboard
+	board.Meta.Time = "2024-11-01 20:15:00.4242"
+	updated, ok := board.UpdatedAt()
+	if !ok || !updated.Equal(time.Date(2024, 11, 1, 20, 15, 0, 424200000, time.UTC)) {
+		t.Fatalf("UpdatedAt = %v, %v", updated, ok)
+	}
+
+	board.Meta.Time = "now"
+	if _, ok := board.UpdatedAt(); ok {
+		t.Fatal("expected no time from an unreadable meta.time")
+	}
+}
+
+func TestGameSummary_SetUpdated(t *testing.T) {
+	updated := time.Date(2024, 11, 1, 20, 15, 0, 0, time.UTC)
+	live := nba.NewGameSummary(nba.Game{GameStatus: nba.StatusLive, Period: 3})
+
+	live.SetUpdated(updated, updated.Add(12*time.Second))
+	if live.LastUpdated != "2024-11-01T20:15:00Z" || live.Age != 12 || live.Stale {
+		t.Fatalf("fresh feed: %+v", live)
+	}
+
+	live.SetUpdated(updated, updated.Add(nba.StaleAfter+time.Second))
+	if !live.Stale {
+		t.Fatalf("expected a live game's old feed to be stale: %+v", live)
+	}
+
+	final := nba.NewGameSummary(nba.Game{GameStatus: nba.StatusFinal})
+	final.SetUpdated(updated, updated.Add(time.Hour))
+	if final.Stale || final.Age != 3600 {
+		t.Fatalf("a final's feed is never stale: %+v", final)
+	}
+
+	// A feed stamped ahead of the local clock is just fresh.
+	live.SetUpdated(updated, updated.Add(-time.Minute))
+	if live.Age != 0 || live.Stale {
+		t.Fatalf("feed ahead of the clock: %+v", live)
+	}
+}
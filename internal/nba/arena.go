@@ -0,0 +1,37 @@
+package nba
+
+import "time"
+
+// neutralSiteZones covers cities that host neutral-site games outside every
+// franchise's home zone, such as the Global Games.
+var neutralSiteZones = map[string]string{
+	"Mexico City": "America/Mexico_City",
+	"Paris":       "Europe/Paris",
+	"London":      "Europe/London",
+	"Berlin":      "Europe/Berlin",
+	"Abu Dhabi":   "Asia/Dubai",
+	"Las Vegas":   "America/Los_Angeles",
+}
+
+// ArenaLocation returns the time zone of the arena hosting g: the neutral
+// site's zone when the feed names one, otherwise the home team's. It returns
+// nil when neither is known.
+func (g Game) ArenaLocation() *time.Location {
+	name := neutralSiteZones[g.ArenaCity]
+	if name == "" {
+		home := TeamByID(g.HomeTeam.ID)
+		if home == nil {
+			home = TeamByTricode(g.HomeTeam.Tricode)
+		}
+		if home == nil {
+			return nil
+		}
+		name = home.TimeZone
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
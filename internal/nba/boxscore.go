@@ -0,0 +1,176 @@
+package nba
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// BoxscoreURL is the default boxscore endpoint; %s is replaced by the game ID.
+var BoxscoreURL = "https://cdn.nba.com/static/json/liveData/boxscore/boxscore_%s.json"
+
+var gameIDPattern = regexp.MustCompile(`^\d{10}$`)
+
+// IsGameID reports whether s looks like an NBA game ID such as "0022300061".
+func IsGameID(s string) bool {
+	return gameIDPattern.MatchString(s)
+}
+
+// BoxscoreStats holds a stat line. The same shape is used for players and
+// team totals.
+type BoxscoreStats struct {
+	Minutes                string  `json:"minutes"` // ISO-8601 duration, e.g. "PT34M12.00S"
+	Points                 int     `json:"points"`
+	FieldGoalsMade         int     `json:"fieldGoalsMade"`
+	FieldGoalsAttempted    int     `json:"fieldGoalsAttempted"`
+	ThreePointersMade      int     `json:"threePointersMade"`
+	ThreePointersAttempted int     `json:"threePointersAttempted"`
+	FreeThrowsMade         int     `json:"freeThrowsMade"`
+	FreeThrowsAttempted    int     `json:"freeThrowsAttempted"`
+	ReboundsOffensive      int     `json:"reboundsOffensive"`
+	ReboundsDefensive      int     `json:"reboundsDefensive"`
+	ReboundsTotal          int     `json:"reboundsTotal"`
+	Assists                int     `json:"assists"`
+	Steals                 int     `json:"steals"`
+	Blocks                 int     `json:"blocks"`
+	Turnovers              int     `json:"turnovers"`
+	FoulsPersonal          int     `json:"foulsPersonal"`
+	PlusMinusPoints        float64 `json:"plusMinusPoints"`
+}
+
+// MinutesPlayed returns whole minutes played, rounded down.
+func (s BoxscoreStats) MinutesPlayed() int {
+	d, _ := parseISODuration(s.Minutes)
+	return int(d.Minutes())
+}
+
+type BoxscorePlayer struct {
+	PersonID              int           `json:"personId"`
+	Name                  string        `json:"name"`
+	NameI                 string        `json:"nameI"` // e.g. "J. Tatum"
+	JerseyNum             string        `json:"jerseyNum"`
+	Position              string        `json:"position,omitempty"`
+	Starter               string        `json:"starter"` // "1" or "0"
+	OnCourt               string        `json:"oncourt"`
+	Played                string        `json:"played"`
+	Status                string        `json:"status"` // "ACTIVE" or "INACTIVE"
+	NotPlayingReason      string        `json:"notPlayingReason,omitempty"`
+	NotPlayingDescription string        `json:"notPlayingDescription,omitempty"`
+	Statistics            BoxscoreStats `json:"statistics"`
+}
+
+// IsStarter reports whether the player started the game.
+func (p BoxscorePlayer) IsStarter() bool {
+	return p.Starter == "1"
+}
+
+// DidNotPlay reports whether the player has not logged any time.
+func (p BoxscorePlayer) DidNotPlay() bool {
+	return p.Played != "1"
+}
+
+// DNPReason describes why the player did not play, e.g. "Coach's Decision"
+// or "Injury/Illness - Left Ankle; Sprain".
+func (p BoxscorePlayer) DNPReason() string {
+	if p.NotPlayingDescription != "" {
+		return p.NotPlayingDescription
+	}
+	switch p.NotPlayingReason {
+	case "DNP_COACH":
+		return "Coach's Decision"
+	case "INACTIVE_INJURY", "DNP_INJURY":
+		return "Injury/Illness"
+	case "INACTIVE_GLEAGUE_TWOWAY":
+		return "G League - Two-Way"
+	case "":
+		if p.Status == "INACTIVE" {
+			return "Inactive"
+		}
+		return "Did not play"
+	}
+	return p.NotPlayingReason
+}
+
+// DisplayName returns the abbreviated name, falling back to the full name.
+func (p BoxscorePlayer) DisplayName() string {
+	if p.NameI != "" {
+		return p.NameI
+	}
+	return p.Name
+}
+
+type BoxscoreTeam struct {
+	Team
+	Players    []BoxscorePlayer `json:"players"`
+	Statistics BoxscoreStats    `json:"statistics"`
+}
+
+// Starters returns the players who started the game.
+func (t BoxscoreTeam) Starters() []BoxscorePlayer {
+	var out []BoxscorePlayer
+	for _, p := range t.Players {
+		if p.IsStarter() {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Bench returns the reserves who have played.
+func (t BoxscoreTeam) Bench() []BoxscorePlayer {
+	var out []BoxscorePlayer
+	for _, p := range t.Players {
+		if !p.IsStarter() && !p.DidNotPlay() {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Inactive returns the reserves who have not played, with DNPReason set.
+func (t BoxscoreTeam) Inactive() []BoxscorePlayer {
+	var out []BoxscorePlayer
+	for _, p := range t.Players {
+		if !p.IsStarter() && p.DidNotPlay() {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+type BoxscoreGame struct {
+	ID             string       `json:"gameId"`
+	GameStatus     int          `json:"gameStatus"`
+	GameStatusText string       `json:"gameStatusText"`
+	Period         int          `json:"period,omitempty"`
+	GameClock      GameClock    `json:"gameClock,omitempty"`
+	GameTimeUTC    string       `json:"gameTimeUTC"`
+	HomeTeam       BoxscoreTeam `json:"homeTeam"`
+	AwayTeam       BoxscoreTeam `json:"awayTeam"`
+}
+
+type Boxscore struct {
+	Game BoxscoreGame `json:"game"`
+	Meta struct {
+		Time string `json:"time"`
+	} `json:"meta"`
+}
+
+// FetchBoxscore fetches the live boxscore for a game using the default endpoints.
+func FetchBoxscore(gameID string) (*Boxscore, error) {
+	return NewClient().FetchBoxscore(context.Background(), gameID)
+}
+
+// FetchBoxscore fetches the live boxscore for a game. The CDN answers 404
+// until shortly before tip-off.
+func (c *Client) FetchBoxscore(ctx context.Context, gameID string) (*Boxscore, error) {
+	if !IsGameID(gameID) {
+		return nil, fmt.Errorf("invalid game ID %q", gameID)
+	}
+
+	var data Boxscore
+	if err := c.fetchJSON(ctx, fmt.Sprintf(c.BoxscoreURL, gameID), c.ScoreboardTTL, "boxscore", &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
@@ -0,0 +1,87 @@
+package nba
+
+// GameChanges describes what changed in a game between two refreshes.
+type GameChanges struct {
+	HomeDelta     int  `json:"home_delta"`
+	AwayDelta     int  `json:"away_delta"`
+	PeriodChanged bool `json:"period_changed"`
+	StatusChanged bool `json:"status_changed"`
+	NewHomeLeader bool `json:"new_home_leader"`
+	NewAwayLeader bool `json:"new_away_leader"`
+	LeadChanged   bool `json:"lead_changed"`
+}
+
+// Any reports whether anything changed.
+func (c GameChanges) Any() bool {
+	return c != GameChanges{}
+}
+
+// CompareGames reports what changed from prev to cur. Both must be snapshots
+// of the same game. It only sees the two snapshots, so a lead that changed
+// hands by way of a tie in prev isn't a lead change; a GameTracker
+// remembers who led before the tie.
+func CompareGames(prev, cur Game) GameChanges {
+	return GameChanges{
+		HomeDelta:     cur.HomeTeam.Score - prev.HomeTeam.Score,
+		AwayDelta:     cur.AwayTeam.Score - prev.AwayTeam.Score,
+		PeriodChanged: cur.Period != prev.Period,
+		StatusChanged: cur.GameStatus != prev.GameStatus,
+		NewHomeLeader: leaderChanged(prev.GameLeaders.HomeLeaders, cur.GameLeaders.HomeLeaders),
+		NewAwayLeader: leaderChanged(prev.GameLeaders.AwayLeaders, cur.GameLeaders.AwayLeaders),
+		LeadChanged:   leadChanged(prev.Lead(), cur.Lead()),
+	}
+}
+
+// GameTracker follows one game across refreshes, comparing each snapshot
+// with the one before like CompareGames, but counting a lead change
+// against the last team to lead, however many tied snapshots came between.
+type GameTracker struct {
+	last Game
+	lead Lead
+}
+
+// NewGameTracker starts following a game from its snapshot g.
+func NewGameTracker(g Game) *GameTracker {
+	return &GameTracker{last: g, lead: g.Lead()}
+}
+
+// Update reports what changed since the last snapshot and remembers cur.
+func (t *GameTracker) Update(cur Game) GameChanges {
+	c := CompareGames(t.last, cur)
+	c.LeadChanged = leadChanged(t.lead, cur.Lead())
+	if lead := cur.Lead(); lead != Tied {
+		t.lead = lead
+	}
+	t.last = cur
+	return c
+}
+
+func leaderChanged(prev, cur Leader) bool {
+	return prev.PersonID != 0 && cur.PersonID != 0 && prev.PersonID != cur.PersonID
+}
+
+// Lead is which team is ahead in a game.
+type Lead int
+
+const (
+	AwayLead Lead = -1
+	Tied     Lead = 0
+	HomeLead Lead = 1
+)
+
+// Lead returns which team is ahead in g.
+func (g Game) Lead() Lead {
+	switch {
+	case g.HomeTeam.Score > g.AwayTeam.Score:
+		return HomeLead
+	case g.HomeTeam.Score < g.AwayTeam.Score:
+		return AwayLead
+	}
+	return Tied
+}
+
+// leadChanged reports whether the other team from prev is now ahead. Ties
+// don't count.
+func leadChanged(prev, cur Lead) bool {
+	return prev != Tied && cur != Tied && prev != cur
+}
@@ -0,0 +1,61 @@
+package nba_test
+
+import (
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestCompareGames(t *testing.T) {
+	prev := nba.Game{Period: 3, GameStatus: 2, HomeTeam: nba.Team{Score: 70}, AwayTeam: nba.Team{Score: 72}}
+	prev.GameLeaders.HomeLeaders.PersonID = 1
+	cur := nba.Game{Period: 4, GameStatus: 2, HomeTeam: nba.Team{Score: 75}, AwayTeam: nba.Team{Score: 72}}
+	cur.GameLeaders.HomeLeaders.PersonID = 2
+
+	c := nba.CompareGames(prev, cur)
+	if c.HomeDelta != 5 || c.AwayDelta != 0 {
+		t.Fatalf("unexpected deltas: %+v", c)
+	}
+	if !c.PeriodChanged || c.StatusChanged {
+		t.Fatalf("unexpected period/status flags: %+v", c)
+	}
+	if !c.NewHomeLeader || c.NewAwayLeader {
+		t.Fatalf("unexpected leader flags: %+v", c)
+	}
+	if !c.LeadChanged {
+		t.Fatalf("expected lead change: %+v", c)
+	}
+	if !c.Any() {
+		t.Fatalf("expected Any to be true")
+	}
+}
+
+func TestCompareGames_NoChange(t *testing.T) {
+	g := nba.Game{Period: 2, GameStatus: 2, HomeTeam: nba.Team{Score: 40}, AwayTeam: nba.Team{Score: 40}}
+	if nba.CompareGames(g, g).Any() {
+		t.Fatalf("identical snapshots should report no change")
+	}
+}
+
+func TestGameTracker_LeadChangeThroughTie(t *testing.T) {
+	score := func(home, away int) nba.Game {
+		return nba.Game{Period: 1, GameStatus: 2, HomeTeam: nba.Team{Score: home}, AwayTeam: nba.Team{Score: away}}
+	}
+
+	tracker := nba.NewGameTracker(score(2, 0))
+	if c := tracker.Update(score(2, 2)); c.LeadChanged || c.AwayDelta != 2 {
+		t.Fatalf("a tie isn't a lead change: %+v", c)
+	}
+	if c := tracker.Update(score(2, 5)); !c.LeadChanged {
+		t.Fatalf("the away team took the lead through the tie: %+v", c)
+	}
+	if nba.CompareGames(score(2, 2), score(2, 5)).LeadChanged {
+		t.Fatal("CompareGames alone can't know who led before the tie")
+	}
+
+	tracker = nba.NewGameTracker(score(4, 2))
+	tracker.Update(score(4, 4))
+	if c := tracker.Update(score(6, 4)); c.LeadChanged {
+		t.Fatalf("the same team back in front isn't a lead change: %+v", c)
+	}
+}
@@ -0,0 +1,238 @@
+package nba
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/internetdrew/bball/internal/cache"
+)
+
+// DefaultUserAgent is sent with every request unless the client overrides it.
+const DefaultUserAgent = "bball (+https://github.com/internetdrew/bball)"
+
+// DefaultTimeout bounds a single request made by a client created with NewClient.
+const DefaultTimeout = 15 * time.Second
+
+// Default freshness windows for cached responses. The schedule changes a few
+// times a day at most; the scoreboard changes every possession.
+const (
+	DefaultScheduleTTL   = 6 * time.Hour
+	DefaultScoreboardTTL = 10 * time.Second
+)
+
+// DefaultScheduleWindow is how many games FetchTeamSchedule returns.
+const DefaultScheduleWindow = 5
+
+// Default retry policy for transient CDN failures.
+const (
+	DefaultMaxRetries   = 2
+	DefaultRetryBackoff = 250 * time.Millisecond
+
+	maxBackoff = 30 * time.Second
+)
+
+// Client fetches data from the NBA's public JSON endpoints. The zero value is
+// not ready to use; create one with NewClient and adjust the fields as needed.
+// A Client is safe for concurrent use as long as its fields are not modified.
+//
+// When Cache is set, responses are stored on disk and reused while they are
+// younger than the matching TTL; older entries are revalidated with
+// If-None-Match/If-Modified-Since instead of being downloaded again.
+//
+// When Offline is set, only the cache is consulted: entries are used however
+// old they are, and a URL that was never cached fails with ErrNotCached.
+//
+// Requests that fail with a transient status (429, 5xx) or a timeout are
+// retried up to MaxRetries times, starting at RetryBackoff and doubling.
+type Client struct {
+	HTTPClient        *http.Client
+	ScoreboardURL     string
+	LeagueScheduleURL string
+	BoxscoreURL       string // format string taking the game ID
+	PlayByPlayURL     string // format string taking the game ID
+	UserAgent         string
+
+	Cache         *cache.Cache
+	ScheduleTTL   time.Duration
+	ScoreboardTTL time.Duration
+	Offline       bool
+
+	MaxRetries   int
+	RetryBackoff time.Duration
+
+	ScheduleWindow int
+}
+
+// NewClient returns a client that talks to the NBA CDN with sensible defaults.
+func NewClient() *Client {
+	return &Client{
+		HTTPClient:        &http.Client{Timeout: DefaultTimeout},
+		ScoreboardURL:     ScoreboardURL,
+		LeagueScheduleURL: LeagueScheduleURL,
+		BoxscoreURL:       BoxscoreURL,
+		PlayByPlayURL:     PlayByPlayURL,
+		UserAgent:         DefaultUserAgent,
+		ScheduleTTL:       DefaultScheduleTTL,
+		ScoreboardTTL:     DefaultScoreboardTTL,
+		MaxRetries:        DefaultMaxRetries,
+		RetryBackoff:      DefaultRetryBackoff,
+		ScheduleWindow:    DefaultScheduleWindow,
+	}
+}
+
+// fetchJSON fetches url and decodes it into v, naming the feed what in
+// errors. The body is only cached once it decodes, so an error page the CDN
+// serves with a 200 never stands in for the data.
+func (c *Client) fetchJSON(ctx context.Context, url string, ttl time.Duration, what string, v any) error {
+	body, store, err := c.fetch(ctx, url, ttl)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", what, err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return decodeError(what, err)
+	}
+	if err := store(); err != nil {
+		return fmt.Errorf("failed to cache %s: %w", what, err)
+	}
+	return nil
+}
+
+// fetch returns the body for url, serving it from the cache while it is
+// younger than ttl and revalidating it once it is not. Transient failures are
+// retried with jittered exponential backoff. store records a 200 body, or a
+// revalidation, in the cache; the caller runs it once the body checks out.
+func (c *Client) fetch(ctx context.Context, url string, ttl time.Duration) (body []byte, store func() error, err error) {
+	var entry *cache.Entry
+	var cached []byte
+	if c.Cache != nil {
+		if e, body, err := c.Cache.Get(url); err == nil {
+			if e.Fresh(ttl) || c.Offline {
+				return body, noStore, nil
+			}
+			entry, cached = e, body
+		}
+	}
+	if c.Offline {
+		return nil, nil, fmt.Errorf("%s: %w", url, ErrNotCached)
+	}
+
+	for attempt := 0; ; attempt++ {
+		body, store, err := c.fetchOnce(ctx, url, entry, cached)
+		if err == nil {
+			return body, store, nil
+		}
+		if attempt >= c.MaxRetries || !retryable(ctx, err) {
+			return nil, nil, err
+		}
+
+		timer := time.NewTimer(c.backoff(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func noStore() error { return nil }
+
+func (c *Client) fetchOnce(ctx context.Context, url string, entry *cache.Entry, cached []byte) ([]byte, func() error, error) {
+	res, err := c.get(ctx, url, entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified && entry != nil {
+		return cached, func() error { return c.Cache.Touch(url) }, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, newHTTPError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.Cache == nil {
+		return body, noStore, nil
+	}
+	fresh := cache.Entry{
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+	}
+	return body, func() error { return c.Cache.Put(url, fresh, body) }, nil
+}
+
+// backoff returns how long to wait before retry number attempt+1: the base
+// delay doubled per attempt with up to 50% jitter, or the server's
+// Retry-After if that is longer.
+func (c *Client) backoff(attempt int, err error) time.Duration {
+	d := c.RetryBackoff << attempt
+	if d > 0 {
+		d = d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.RetryAfter > d {
+		d = httpErr.RetryAfter
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// retryable reports whether err is a transient status or a timeout.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Temporary()
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// get issues a GET request for url, honoring ctx and the client's user agent.
+// When entry is non-nil its validators are sent so the server can reply 304.
+func (c *Client) get(ctx context.Context, url string, entry *cache.Entry) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	req.Header.Set("Accept", "application/json")
+	if entry != nil {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return httpClient.Do(req)
+}
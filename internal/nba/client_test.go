@@ -0,0 +1,259 @@
+package nba_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/internetdrew/bball/internal/cache"
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestClient_SideBySide(t *testing.T) {
+	serve := func(tricode string) *httptest.Server {
+		resp := nba.Scoreboard{}
+		resp.Scoreboard.Games = []nba.Game{{HomeTeam: nba.Team{Tricode: tricode}}}
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(resp)
+		}))
+	}
+	prod, fixture := serve("BOS"), serve("NYK")
+	defer prod.Close()
+	defer fixture.Close()
+
+	a, b := nba.NewClient(), nba.NewClient()
+	a.ScoreboardURL = prod.URL
+	b.ScoreboardURL = fixture.URL
+
+	boardA, err := a.FetchScoreboard(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	boardB, err := b.FetchScoreboard(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if boardA.Scoreboard.Games[0].HomeTeam.Tricode != "BOS" || boardB.Scoreboard.Games[0].HomeTeam.Tricode != "NYK" {
+		t.Fatalf("clients should not share endpoints")
+	}
+}
+
+func TestClient_SendsUserAgent(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		json.NewEncoder(w).Encode(nba.Scoreboard{})
+	}))
+	defer server.Close()
+
+	c := nba.NewClient()
+	c.ScoreboardURL = server.URL
+	c.UserAgent = "bball-test/1.0"
+	if _, err := c.FetchScoreboard(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "bball-test/1.0" {
+		t.Fatalf("expected custom user agent, got %q", got)
+	}
+}
+
+func TestClient_HonorsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(nba.Scoreboard{})
+	}))
+	defer server.Close()
+
+	c := nba.NewClient()
+	c.ScoreboardURL = server.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.FetchScoreboard(ctx); err == nil {
+		t.Fatalf("expected error for cancelled context, got nil")
+	}
+}
+
+func TestClient_CacheServesFreshEntries(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		json.NewEncoder(w).Encode(nba.Scoreboard{})
+	}))
+	defer server.Close()
+
+	c := nba.NewClient()
+	c.ScoreboardURL = server.URL
+	c.Cache = cache.New(t.TempDir())
+	c.ScoreboardTTL = time.Minute
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.FetchScoreboard(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if hits != 1 {
+		t.Fatalf("expected 1 upstream request, got %d", hits)
+	}
+}
+
+func TestClient_DoesNotCacheUndecodableBody(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits == 1 {
+			w.Write([]byte("<html>Down for maintenance</html>"))
+			return
+		}
+		json.NewEncoder(w).Encode(nba.LeagueScheduleResponse{})
+	}))
+	defer server.Close()
+
+	c := nba.NewClient()
+	c.LeagueScheduleURL = server.URL
+	c.Cache = cache.New(t.TempDir())
+	c.ScheduleTTL = time.Hour
+
+	if _, err := c.FetchLeagueSchedule(context.Background()); !errors.Is(err, nba.ErrDecode) {
+		t.Fatalf("expected ErrDecode for the maintenance page, got %v", err)
+	}
+	if _, err := c.FetchLeagueSchedule(context.Background()); err != nil {
+		t.Fatalf("expected the next fetch to recover, got %v", err)
+	}
+	if hits != 2 {
+		t.Fatalf("expected the maintenance page not to be served from the cache, got %d requests", hits)
+	}
+	if _, err := c.FetchLeagueSchedule(context.Background()); err != nil || hits != 2 {
+		t.Fatalf("expected the good schedule to be cached: %v after %d requests", err, hits)
+	}
+}
+
+func TestClient_ReportsCacheWriteErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(nba.Scoreboard{})
+	}))
+	defer server.Close()
+
+	// A file where the cache directory should be can't be written to.
+	dir := filepath.Join(t.TempDir(), "cache")
+	if err := os.WriteFile(dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := nba.NewClient()
+	c.ScoreboardURL = server.URL
+	c.Cache = cache.New(dir)
+
+	if _, err := c.FetchScoreboard(context.Background()); err == nil {
+		t.Fatal("expected the cache write error")
+	}
+}
+
+func TestClient_CacheRevalidatesWithETag(t *testing.T) {
+	var conditional int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		resp := nba.Scoreboard{}
+		resp.Scoreboard.Games = []nba.Game{{ID: "0022300001"}}
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	c := nba.NewClient()
+	c.ScoreboardURL = server.URL
+	c.Cache = cache.New(t.TempDir())
+	c.ScoreboardTTL = 0 // always revalidate
+
+	if _, err := c.FetchScoreboard(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	board, err := c.FetchScoreboard(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conditional != 1 {
+		t.Fatalf("expected a conditional request, got %d", conditional)
+	}
+	if len(board.Scoreboard.Games) != 1 || board.Scoreboard.Games[0].ID != "0022300001" {
+		t.Fatalf("expected cached body on 304, got %+v", board.Scoreboard.Games)
+	}
+}
+
+func TestClient_OfflineUsesStaleCache(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		resp := nba.Scoreboard{}
+		resp.Scoreboard.Games = []nba.Game{{ID: "0022300001"}}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	c := nba.NewClient()
+	c.ScoreboardURL = server.URL
+	c.Cache = cache.New(t.TempDir())
+	c.ScoreboardTTL = 0
+
+	if _, err := c.FetchScoreboard(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Offline = true
+	board, err := c.FetchScoreboard(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hits != 1 || len(board.Scoreboard.Games) != 1 {
+		t.Fatalf("expected the stale cached body without a request, got %d requests", hits)
+	}
+
+	c.ScoreboardURL = server.URL + "/other"
+	if _, err := c.FetchScoreboard(context.Background()); !errors.Is(err, nba.ErrNotCached) {
+		t.Fatalf("expected ErrNotCached, got %v", err)
+	}
+}
+
+func TestFetchBoxscore_DecodesPlayers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/boxscore_0022300061.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"game":{"gameId":"0022300061","gameStatus":3,"homeTeam":{"teamTricode":"BOS","players":[
+			{"nameI":"J. Tatum","starter":"1","played":"1","statistics":{"minutes":"PT36M12.00S","points":31}},
+			{"nameI":"N. Queta","starter":"0","played":"0","notPlayingReason":"DNP_COACH"}]}}}`))
+	}))
+	defer server.Close()
+
+	c := nba.NewClient()
+	c.BoxscoreURL = server.URL + "/boxscore_%s.json"
+
+	box, err := c.FetchBoxscore(context.Background(), "0022300061")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	home := box.Game.HomeTeam
+	if home.Tricode != "BOS" || len(home.Starters()) != 1 || len(home.Inactive()) != 1 {
+		t.Fatalf("unexpected home team: %+v", home)
+	}
+	if got := home.Starters()[0].Statistics.MinutesPlayed(); got != 36 {
+		t.Fatalf("expected 36 minutes, got %d", got)
+	}
+	if got := home.Inactive()[0].DNPReason(); got != "Coach's Decision" {
+		t.Fatalf("unexpected DNP reason %q", got)
+	}
+}
+
+func TestFetchBoxscore_InvalidGameID(t *testing.T) {
+	if _, err := nba.NewClient().FetchBoxscore(context.Background(), "../etc"); err == nil {
+		t.Fatalf("expected error for invalid game ID")
+	}
+}
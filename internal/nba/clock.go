@@ -0,0 +1,156 @@
+package nba
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GameClock is the time left in a period as the feeds send it: usually an
+// ISO-8601 duration like "PT05M21.00S", sometimes "PT05:21.00", and in a
+// few older payloads plain "5:21".
+type GameClock string
+
+var (
+	isoDurationPattern = regexp.MustCompile(`^PT(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?$`)
+	clockPattern       = regexp.MustCompile(`^(?:PT)?(\d+):(\d+(?:\.\d+)?)$`)
+)
+
+// parseISODuration parses the subset of ISO-8601 durations used by the
+// liveData feeds ("PT34M12.00S"), and the "PT05:21.00" form the scoreboard
+// sometimes uses for the game clock. ok is false for anything else,
+// including the bare "PT" sent before tip-off.
+func parseISODuration(s string) (d time.Duration, ok bool) {
+	s = strings.TrimSpace(s)
+	if m := clockPattern.FindStringSubmatch(s); m != nil {
+		min, _ := strconv.Atoi(m[1])
+		sec, _ := strconv.ParseFloat(m[2], 64)
+		return time.Duration(min)*time.Minute + time.Duration(sec*float64(time.Second)), true
+	}
+	m := isoDurationPattern.FindStringSubmatch(s)
+	if m == nil || s == "PT" {
+		return 0, false
+	}
+	if h, err := strconv.Atoi(m[1]); err == nil {
+		d += time.Duration(h) * time.Hour
+	}
+	if min, err := strconv.Atoi(m[2]); err == nil {
+		d += time.Duration(min) * time.Minute
+	}
+	if sec, err := strconv.ParseFloat(m[3], 64); err == nil {
+		d += time.Duration(sec * float64(time.Second))
+	}
+	return d, true
+}
+
+// Remaining returns the time left, and false when the clock is empty or
+// can't be read.
+func (c GameClock) Remaining() (time.Duration, bool) {
+	return parseISODuration(string(c))
+}
+
+// Expired reports whether the clock reads zero.
+func (c GameClock) Expired() bool {
+	d, ok := c.Remaining()
+	return ok && d == 0
+}
+
+// String returns the clock the way a broadcast shows it: "5:21", or
+// tenths of a second in the last minute, like "42.3". It's "" when the
+// clock can't be read.
+func (c GameClock) String() string {
+	d, ok := c.Remaining()
+	switch {
+	case !ok:
+		return ""
+	case d < time.Minute && d > 0:
+		return fmt.Sprintf("%.1f", d.Seconds())
+	}
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// PeriodLabel returns "Q1".."Q4", then "OT", "2OT", "3OT", ...
+func PeriodLabel(period int) string {
+	switch {
+	case period <= 4:
+		return fmt.Sprintf("Q%d", period)
+	case period == 5:
+		return "OT"
+	default:
+		return fmt.Sprintf("%dOT", period-4)
+	}
+}
+
+// Break is a pause in a game the clock alone doesn't show.
+type Break string
+
+const (
+	NoBreak     Break = ""
+	TipOffSoon  Break = "tip_off_soon"
+	EndOfPeriod Break = "end_of_period"
+	Halftime    Break = "halftime"
+)
+
+// Clock is where a game is in time: the period, the time left in it and
+// whether play is stopped for a break.
+type Clock struct {
+	Period    int
+	Remaining GameClock
+	Break     Break
+}
+
+// Clock reads the game's period and clock, and any break from its status
+// text ("Halftime", "End of 3rd Qtr", "Pregame"). A live game whose clock
+// has run out is at the end of its period even before the text says so.
+func (g Game) Clock() Clock {
+	c := Clock{Period: g.Period, Remaining: g.GameClock}
+
+	text := strings.ToLower(strings.TrimSpace(g.GameStatusText))
+	switch {
+	case strings.HasPrefix(text, "half"):
+		c.Break = Halftime
+	case strings.HasPrefix(text, "end of"):
+		c.Break = EndOfPeriod
+	case strings.Contains(text, "pregame"), strings.Contains(text, "tip-off soon"), strings.Contains(text, "starting soon"):
+		c.Break = TipOffSoon
+	case g.GameStatus == StatusLive && c.Remaining.Expired():
+		c.Break = EndOfPeriod
+	case g.GameStatus == StatusLive && g.Period == 0:
+		c.Break = TipOffSoon
+	}
+	if c.Break == EndOfPeriod && c.Period == 2 {
+		c.Break = Halftime
+	}
+	return c
+}
+
+// Ended reports whether play is stopped between periods.
+func (c Clock) Ended() bool {
+	return c.Break == EndOfPeriod || c.Break == Halftime
+}
+
+// String returns e.g. "Q3 5:21", "2OT 42.1", "Halftime", "End of Q3" or
+// "Tip-off soon". The period is shown alone when the clock can't be read.
+func (c Clock) String() string {
+	switch c.Break {
+	case TipOffSoon:
+		return "Tip-off soon"
+	case Halftime:
+		return "Halftime"
+	case EndOfPeriod:
+		return "End of " + PeriodLabel(c.Period)
+	}
+	if clock := c.Remaining.String(); clock != "" {
+		return PeriodLabel(c.Period) + " " + clock
+	}
+	return PeriodLabel(c.Period)
+}
+
+// PeriodEnded reports whether a live game is between periods: the clock
+// has run out, or the status text says the period is over ("End of 3rd
+// Qtr", "Halftime").
+func (g Game) PeriodEnded() bool {
+	return g.State().IsLive() && g.Clock().Ended()
+}
@@ -0,0 +1,76 @@
+package nba_test
+
+import (
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestGameClock(t *testing.T) {
+	for clock, want := range map[nba.GameClock]string{
+		"PT05M21.00S": "5:21",
+		"PT05:21.00":  "5:21",
+		"5:21":        "5:21",
+		"PT12M00.00S": "12:00",
+		"PT00M42.30S": "42.3",
+		"PT00M00.00S": "0:00",
+		"PT":          "",
+		"":            "",
+	} {
+		if got := clock.String(); got != want {
+			t.Errorf("GameClock(%q) = %q, want %q", string(clock), got, want)
+		}
+	}
+	if !nba.GameClock("PT00:00.00").Expired() || nba.GameClock("").Expired() {
+		t.Fatal("Expired should only hold for a clock that reads zero")
+	}
+}
+
+func TestPeriodLabel(t *testing.T) {
+	for period, want := range map[int]string{1: "Q1", 4: "Q4", 5: "OT", 6: "2OT", 7: "3OT"} {
+		if got := nba.PeriodLabel(period); got != want {
+			t.Errorf("PeriodLabel(%d) = %q, want %q", period, got, want)
+		}
+	}
+}
+
+func TestGame_Clock(t *testing.T) {
+	tests := []struct {
+		game nba.Game
+		want string
+	}{
+		{nba.Game{GameStatus: 2, Period: 3, GameClock: "PT05M21.00S"}, "Q3 5:21"},
+		{nba.Game{GameStatus: 2, Period: 6, GameClock: "PT00:42.10"}, "2OT 42.1"},
+		{nba.Game{GameStatus: 2, Period: 2, GameStatusText: "Halftime"}, "Halftime"},
+		{nba.Game{GameStatus: 2, Period: 2, GameClock: "PT00M00.00S"}, "Halftime"},
+		{nba.Game{GameStatus: 2, Period: 3, GameStatusText: "End of 3rd Qtr"}, "End of Q3"},
+		{nba.Game{GameStatus: 2, Period: 5, GameClock: "PT00M00.00S"}, "End of OT"},
+		{nba.Game{GameStatus: 1, GameStatusText: "Pregame"}, "Tip-off soon"},
+		{nba.Game{GameStatus: 2, Period: 0, GameClock: "PT"}, "Tip-off soon"},
+		{nba.Game{GameStatus: 2, Period: 4, GameClock: "PT"}, "Q4"},
+	}
+	for _, tt := range tests {
+		if got := tt.game.Clock().String(); got != tt.want {
+			t.Errorf("Clock(%d, %q, %q) = %q, want %q", tt.game.Period, string(tt.game.GameClock), tt.game.GameStatusText, got, tt.want)
+		}
+	}
+}
+
+func TestGame_PeriodEnded(t *testing.T) {
+	cases := []struct {
+		game nba.Game
+		want bool
+	}{
+		{nba.Game{GameStatus: 2, GameClock: "PT00M00.00S"}, true},
+		{nba.Game{GameStatus: 2, GameClock: "PT04M12.00S"}, false},
+		{nba.Game{GameStatus: 2, GameStatusText: "Halftime"}, true},
+		{nba.Game{GameStatus: 2, GameStatusText: "End of 3rd Qtr", GameClock: ""}, true},
+		{nba.Game{GameStatus: 2, GameStatusText: "Q2 5:12"}, false},
+		{nba.Game{GameStatus: 3, GameClock: "PT00M00.00S"}, false},
+	}
+	for _, c := range cases {
+		if got := c.game.PeriodEnded(); got != c.want {
+			t.Errorf("PeriodEnded(%q, %q) = %v, want %v", c.game.GameStatusText, string(c.game.GameClock), got, c.want)
+		}
+	}
+}
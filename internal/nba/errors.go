@@ -0,0 +1,62 @@
+package nba
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrDecode indicates a response could not be decoded as the expected JSON.
+// The NBA CDN sometimes serves an HTML error page with a 200 status.
+var ErrDecode = errors.New("unexpected response format")
+
+// ErrRateLimited indicates the CDN is throttling requests. It matches any
+// *HTTPError with status 429.
+var ErrRateLimited = errors.New("rate limited")
+
+// ErrNotCached indicates an offline client has no cached copy of a response.
+var ErrNotCached = errors.New("not in the cache")
+
+// HTTPError is returned when an endpoint answers with a non-200 status.
+type HTTPError struct {
+	URL        string
+	StatusCode int
+	// RetryAfter is the server's requested delay, if it sent one.
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Is lets errors.Is(err, ErrRateLimited) match throttling responses.
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrRateLimited && e.StatusCode == http.StatusTooManyRequests
+}
+
+// Temporary reports whether the request is worth retrying.
+func (e *HTTPError) Temporary() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func newHTTPError(res *http.Response) *HTTPError {
+	err := &HTTPError{URL: res.Request.URL.String(), StatusCode: res.StatusCode}
+	if secs, convErr := strconv.Atoi(res.Header.Get("Retry-After")); convErr == nil && secs > 0 {
+		err.RetryAfter = time.Duration(secs) * time.Second
+	}
+	return err
+}
+
+func decodeError(what string, err error) error {
+	return fmt.Errorf("failed to parse %s: %w: %w", what, ErrDecode, err)
+}
@@ -0,0 +1,87 @@
+package nba_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func newTestClient(url string) *nba.Client {
+	c := nba.NewClient()
+	c.ScoreboardURL = url
+	c.LeagueScheduleURL = url
+	c.RetryBackoff = time.Millisecond
+	return c
+}
+
+func TestFetch_RetriesTransientStatus(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(nba.Scoreboard{})
+	}))
+	defer server.Close()
+
+	if _, err := newTestClient(server.URL).FetchScoreboard(context.Background()); err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if hits != 3 {
+		t.Fatalf("expected 3 attempts, got %d", hits)
+	}
+}
+
+func TestFetch_DoesNotRetryClientErrors(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "<html>Access Denied</html>")
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).FetchScoreboard(context.Background())
+	var httpErr *nba.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected *HTTPError with 403, got %v", err)
+	}
+	if hits != 1 {
+		t.Fatalf("expected a single attempt, got %d", hits)
+	}
+}
+
+func TestFetch_RateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.MaxRetries = 0
+	_, err := c.FetchTeamSchedule(context.Background(), "BOS", "upcoming")
+	if !errors.Is(err, nba.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+}
+
+func TestFetch_DecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<html>maintenance</html>")
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).FetchScoreboard(context.Background())
+	if !errors.Is(err, nba.ErrDecode) {
+		t.Fatalf("expected ErrDecode, got %v", err)
+	}
+}
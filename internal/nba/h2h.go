@@ -0,0 +1,75 @@
+package nba
+
+// Meeting is one game between the two teams of a HeadToHead.
+type Meeting struct {
+	Game Game `json:"game"`
+	// Home reports whether the HeadToHead's team was at home.
+	Home bool `json:"home"`
+	// Winner, Margin and Series are only set once the game is final. Margin
+	// is from the team's point of view, and Series is its record in the
+	// series after this game.
+	Winner string  `json:"winner,omitempty"`
+	Margin int     `json:"margin,omitempty"`
+	Series *Record `json:"series,omitempty"`
+}
+
+// HeadToHead is a season series between two teams, from Team's point of
+// view.
+type HeadToHead struct {
+	Team     string    `json:"team"`
+	Opponent string    `json:"opponent"`
+	Meetings []Meeting `json:"meetings"`
+	Record   Record    `json:"record"`
+	// PointDiff is Team's total margin over the completed meetings.
+	PointDiff int    `json:"point_differential"`
+	Home      Record `json:"home"`
+	Away      Record `json:"away"`
+	// Next is the next meeting that hasn't started, if any.
+	Next *Game `json:"next_meeting,omitempty"`
+}
+
+// HeadToHead returns the regular season meetings between team and opponent
+// in the schedule, in schedule order. Preseason, play-in and playoff games,
+// and the NBA Cup final, don't count toward the season series.
+func (s *LeagueScheduleResponse) HeadToHead(team, opponent *Franchise) HeadToHead {
+	h := HeadToHead{Team: team.Tricode, Opponent: opponent.Tricode, Meetings: []Meeting{}}
+
+	for _, gameDate := range s.LeagueSchedule.GameDates {
+		for _, g := range gameDate.Games {
+			if !g.IsRegularSeason() || !gameInvolves(g, team) || !gameInvolves(g, opponent) {
+				continue
+			}
+
+			m := Meeting{Game: g, Home: team.Matches(g.HomeTeam)}
+			switch state := g.State(); {
+			case state.IsUpcoming():
+				if h.Next == nil {
+					next := g
+					h.Next = &next
+				}
+			case state.IsFinal():
+				ours, theirs := g.AwayTeam.Score, g.HomeTeam.Score
+				if m.Home {
+					ours, theirs = theirs, ours
+				}
+				won := ours > theirs
+				m.Winner, m.Margin = opponent.Tricode, ours-theirs
+				if won {
+					m.Winner = team.Tricode
+				}
+
+				h.Record.Add(won)
+				if m.Home {
+					h.Home.Add(won)
+				} else {
+					h.Away.Add(won)
+				}
+				h.PointDiff += m.Margin
+				series := h.Record
+				m.Series = &series
+			}
+			h.Meetings = append(h.Meetings, m)
+		}
+	}
+	return h
+}
@@ -0,0 +1,63 @@
+package nba_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+const h2hSchedule = `{"leagueSchedule":{"gameDates":[
+	{"gameDate":"10/10/2024 00:00:00","games":[
+		{"gameId":"0012400010","gameStatus":3,"homeTeam":{"teamTricode":"NYK","score":120},"awayTeam":{"teamTricode":"BOS","score":90}}]},
+	{"gameDate":"10/22/2024 00:00:00","games":[
+		{"gameId":"0022400061","gameStatus":3,"homeTeam":{"teamTricode":"BOS","score":132},"awayTeam":{"teamTricode":"NYK","score":109}},
+		{"gameId":"0022400062","gameStatus":3,"homeTeam":{"teamTricode":"LAL","score":110},"awayTeam":{"teamTricode":"MIN","score":103}}]},
+	{"gameDate":"02/08/2025 00:00:00","games":[
+		{"gameId":"0022400750","gameStatus":3,"homeTeam":{"teamTricode":"NYK","score":131},"awayTeam":{"teamTricode":"BOS","score":104}}]},
+	{"gameDate":"02/23/2025 00:00:00","games":[
+		{"gameId":"0022400838","gameStatus":3,"homeTeam":{"teamTricode":"BOS","score":118},"awayTeam":{"teamTricode":"NYK","score":105}}]},
+	{"gameDate":"04/08/2025 00:00:00","games":[
+		{"gameId":"0022401150","gameStatus":1,"gameDateTimeUTC":"2025-04-08T23:30:00Z","homeTeam":{"teamTricode":"NYK"},"awayTeam":{"teamTricode":"BOS"}}]},
+	{"gameDate":"05/05/2025 00:00:00","games":[
+		{"gameId":"0042400201","gameStatus":3,"homeTeam":{"teamTricode":"BOS","score":105},"awayTeam":{"teamTricode":"NYK","score":108}}]},
+	{"gameDate":"05/07/2025 00:00:00","games":[
+		{"gameId":"0042400202","gameStatus":1,"gameDateTimeUTC":"2025-05-07T23:30:00Z","homeTeam":{"teamTricode":"BOS"},"awayTeam":{"teamTricode":"NYK"}}]}]}}`
+
+func TestHeadToHead(t *testing.T) {
+	var schedule nba.LeagueScheduleResponse
+	if err := json.Unmarshal([]byte(h2hSchedule), &schedule); err != nil {
+		t.Fatal(err)
+	}
+
+	h := schedule.HeadToHead(nba.TeamByTricode("NYK"), nba.TeamByTricode("BOS"))
+	if len(h.Meetings) != 4 {
+		t.Fatalf("expected 4 meetings without the preseason and playoff games, got %d", len(h.Meetings))
+	}
+	if h.Record.String() != "1-2" || h.PointDiff != -23-13+27 {
+		t.Fatalf("record %s, point differential %d", h.Record, h.PointDiff)
+	}
+	if h.Home.String() != "1-0" || h.Away.String() != "0-2" {
+		t.Fatalf("home %s, away %s", h.Home, h.Away)
+	}
+
+	first := h.Meetings[0]
+	if first.Home || first.Winner != "BOS" || first.Margin != -23 || first.Series.String() != "0-1" {
+		t.Fatalf("unexpected first meeting %+v", first)
+	}
+	if second := h.Meetings[1]; !second.Home || second.Winner != "NYK" || second.Series.String() != "1-1" {
+		t.Fatalf("unexpected second meeting %+v", second)
+	}
+	if last := h.Meetings[3]; last.Winner != "" || last.Series != nil {
+		t.Fatalf("scheduled meeting should have no result: %+v", last)
+	}
+	if h.Next == nil || h.Next.ID != "0022401150" {
+		t.Fatalf("unexpected next meeting %+v", h.Next)
+	}
+
+	// The same series from the other side.
+	r := schedule.HeadToHead(nba.TeamByTricode("BOS"), nba.TeamByTricode("NYK"))
+	if r.Record.String() != "2-1" || r.PointDiff != -h.PointDiff || r.Home.String() != "2-0" {
+		t.Fatalf("reversed: record %s, diff %d, home %s", r.Record, r.PointDiff, r.Home)
+	}
+}
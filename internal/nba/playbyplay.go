@@ -0,0 +1,210 @@
+package nba
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PlayByPlayURL is the default play-by-play endpoint; %s is replaced by the
+// game ID.
+var PlayByPlayURL = "https://cdn.nba.com/static/json/liveData/playbyplay/playbyplay_%s.json"
+
+// ActionKind classifies a play-by-play action.
+type ActionKind string
+
+const (
+	ActionShotMade     ActionKind = "made"
+	ActionShotMissed   ActionKind = "missed"
+	ActionRebound      ActionKind = "rebound"
+	ActionFoul         ActionKind = "foul"
+	ActionTurnover     ActionKind = "turnover"
+	ActionSubstitution ActionKind = "substitution"
+	ActionTimeout      ActionKind = "timeout"
+	ActionJumpBall     ActionKind = "jumpball"
+	ActionPeriodStart  ActionKind = "period-start"
+	ActionPeriodEnd    ActionKind = "period-end"
+	ActionOther        ActionKind = "other"
+)
+
+// actionKindAliases maps user-facing filter names to kinds. "shot" and
+// "period" cover both halves of their pair.
+var actionKindAliases = map[string][]ActionKind{
+	"shot":         {ActionShotMade, ActionShotMissed},
+	"made":         {ActionShotMade},
+	"missed":       {ActionShotMissed},
+	"rebound":      {ActionRebound},
+	"foul":         {ActionFoul},
+	"turnover":     {ActionTurnover},
+	"sub":          {ActionSubstitution},
+	"substitution": {ActionSubstitution},
+	"timeout":      {ActionTimeout},
+	"jumpball":     {ActionJumpBall},
+	"period":       {ActionPeriodStart, ActionPeriodEnd},
+	"period-start": {ActionPeriodStart},
+	"period-end":   {ActionPeriodEnd},
+	"other":        {ActionOther},
+}
+
+// ParseActionKinds parses a comma-separated list such as "shot,foul".
+func ParseActionKinds(s string) ([]ActionKind, error) {
+	var kinds []ActionKind
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		k, ok := actionKindAliases[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown action type %q (try shot, made, missed, rebound, foul, turnover, sub, timeout, period)", name)
+		}
+		kinds = append(kinds, k...)
+	}
+	return kinds, nil
+}
+
+// Action is a single play-by-play event.
+type Action struct {
+	Number       int      `json:"actionNumber"`
+	OrderNumber  int      `json:"orderNumber"`
+	Clock        string   `json:"clock"` // ISO-8601 duration remaining, e.g. "PT11M42.00S"
+	TimeActual   string   `json:"timeActual"`
+	Period       int      `json:"period"`
+	PeriodType   string   `json:"periodType"`
+	TeamID       int      `json:"teamId,omitempty"`
+	TeamTricode  string   `json:"teamTricode,omitempty"`
+	PersonID     int      `json:"personId,omitempty"`
+	PlayerName   string   `json:"playerName,omitempty"`
+	PlayerNameI  string   `json:"playerNameI,omitempty"`
+	ActionType   string   `json:"actionType"` // e.g. "2pt", "3pt", "freethrow", "foul"
+	SubType      string   `json:"subType,omitempty"`
+	Qualifiers   []string `json:"qualifiers,omitempty"`
+	ShotResult   string   `json:"shotResult,omitempty"` // "Made" or "Missed"
+	ShotDistance float64  `json:"shotDistance,omitempty"`
+	IsFieldGoal  int      `json:"isFieldGoal"`
+	X            *float64 `json:"x"`
+	Y            *float64 `json:"y"`
+	ScoreHome    string   `json:"scoreHome"`
+	ScoreAway    string   `json:"scoreAway"`
+	Description  string   `json:"description"`
+}
+
+// Kind classifies the action.
+func (a Action) Kind() ActionKind {
+	switch strings.ToLower(a.ActionType) {
+	case "2pt", "3pt", "freethrow":
+		if strings.EqualFold(a.ShotResult, "Made") {
+			return ActionShotMade
+		}
+		return ActionShotMissed
+	case "rebound":
+		return ActionRebound
+	case "foul":
+		return ActionFoul
+	case "turnover":
+		return ActionTurnover
+	case "substitution":
+		return ActionSubstitution
+	case "timeout":
+		return ActionTimeout
+	case "jumpball":
+		return ActionJumpBall
+	case "period":
+		if strings.EqualFold(a.SubType, "start") {
+			return ActionPeriodStart
+		}
+		return ActionPeriodEnd
+	}
+	return ActionOther
+}
+
+// Score returns the score after the action.
+func (a Action) Score() (home, away int) {
+	home, _ = strconv.Atoi(a.ScoreHome)
+	away, _ = strconv.Atoi(a.ScoreAway)
+	return home, away
+}
+
+// Coordinates returns the court position of the action, if it has one.
+// Coordinates are percentages of court length and width.
+func (a Action) Coordinates() (x, y float64, ok bool) {
+	if a.X == nil || a.Y == nil {
+		return 0, 0, false
+	}
+	return *a.X, *a.Y, true
+}
+
+// ClockDisplay returns the time remaining in the period as "MM:SS".
+func (a Action) ClockDisplay() string {
+	d, _ := parseISODuration(a.Clock)
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+type PlayByPlay struct {
+	Game struct {
+		ID      string   `json:"gameId"`
+		Actions []Action `json:"actions"`
+	} `json:"game"`
+	Meta struct {
+		Time string `json:"time"`
+	} `json:"meta"`
+}
+
+// ActionFilter narrows a list of actions. Zero fields match everything.
+type ActionFilter struct {
+	Period int
+	Team   string // tricode
+	Kinds  []ActionKind
+	LastN  int
+}
+
+// FilterActions returns the actions matching f, in their original order.
+func FilterActions(actions []Action, f ActionFilter) []Action {
+	var out []Action
+	for _, a := range actions {
+		if f.Period != 0 && a.Period != f.Period {
+			continue
+		}
+		if f.Team != "" && !strings.EqualFold(a.TeamTricode, f.Team) {
+			continue
+		}
+		if len(f.Kinds) > 0 && !containsKind(f.Kinds, a.Kind()) {
+			continue
+		}
+		out = append(out, a)
+	}
+
+	if f.LastN > 0 && len(out) > f.LastN {
+		out = out[len(out)-f.LastN:]
+	}
+	return out
+}
+
+func containsKind(kinds []ActionKind, k ActionKind) bool {
+	for _, want := range kinds {
+		if want == k {
+			return true
+		}
+	}
+	return false
+}
+
+// FetchPlayByPlay fetches a game's play-by-play using the default endpoints.
+func FetchPlayByPlay(gameID string) (*PlayByPlay, error) {
+	return NewClient().FetchPlayByPlay(context.Background(), gameID)
+}
+
+// FetchPlayByPlay fetches a game's play-by-play. Like the boxscore, the CDN
+// answers 404 until shortly before tip-off.
+func (c *Client) FetchPlayByPlay(ctx context.Context, gameID string) (*PlayByPlay, error) {
+	if !IsGameID(gameID) {
+		return nil, fmt.Errorf("invalid game ID %q", gameID)
+	}
+
+	var data PlayByPlay
+	if err := c.fetchJSON(ctx, fmt.Sprintf(c.PlayByPlayURL, gameID), c.ScoreboardTTL, "play-by-play", &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
@@ -0,0 +1,87 @@
+package nba_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestAction_Kind(t *testing.T) {
+	cases := []struct {
+		action nba.Action
+		want   nba.ActionKind
+	}{
+		{nba.Action{ActionType: "3pt", ShotResult: "Made"}, nba.ActionShotMade},
+		{nba.Action{ActionType: "2pt", ShotResult: "Missed"}, nba.ActionShotMissed},
+		{nba.Action{ActionType: "freethrow", ShotResult: "Made"}, nba.ActionShotMade},
+		{nba.Action{ActionType: "foul", SubType: "personal"}, nba.ActionFoul},
+		{nba.Action{ActionType: "substitution"}, nba.ActionSubstitution},
+		{nba.Action{ActionType: "period", SubType: "start"}, nba.ActionPeriodStart},
+		{nba.Action{ActionType: "period", SubType: "end"}, nba.ActionPeriodEnd},
+		{nba.Action{ActionType: "instantreplay"}, nba.ActionOther},
+	}
+	for _, tc := range cases {
+		if got := tc.action.Kind(); got != tc.want {
+			t.Errorf("Kind(%s/%s) = %s, want %s", tc.action.ActionType, tc.action.ShotResult, got, tc.want)
+		}
+	}
+}
+
+func TestFilterActions(t *testing.T) {
+	actions := []nba.Action{
+		{Period: 1, TeamTricode: "BOS", ActionType: "3pt", ShotResult: "Made"},
+		{Period: 1, TeamTricode: "NYK", ActionType: "foul"},
+		{Period: 2, TeamTricode: "BOS", ActionType: "2pt", ShotResult: "Missed"},
+		{Period: 2, TeamTricode: "BOS", ActionType: "turnover"},
+		{Period: 2, TeamTricode: "BOS", ActionType: "2pt", ShotResult: "Made"},
+	}
+
+	kinds, err := nba.ParseActionKinds("shot")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := nba.FilterActions(actions, nba.ActionFilter{Period: 2, Team: "bos", Kinds: kinds})
+	if len(out) != 2 {
+		t.Fatalf("expected 2 shots in Q2 for BOS, got %d", len(out))
+	}
+
+	out = nba.FilterActions(actions, nba.ActionFilter{LastN: 2})
+	if len(out) != 2 || out[1].ShotResult != "Made" {
+		t.Fatalf("expected last 2 actions in order, got %+v", out)
+	}
+}
+
+func TestParseActionKinds_Unknown(t *testing.T) {
+	if _, err := nba.ParseActionKinds("dunk"); err == nil {
+		t.Fatalf("expected error for unknown action type")
+	}
+}
+
+func TestFetchPlayByPlay_ScoreAndCoordinates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"game":{"gameId":"0022300061","actions":[
+			{"actionNumber":7,"clock":"PT11M42.00S","period":1,"actionType":"3pt","shotResult":"Made","x":12.5,"y":40.1,"scoreHome":"3","scoreAway":"0"}]}}`))
+	}))
+	defer server.Close()
+
+	c := nba.NewClient()
+	c.PlayByPlayURL = server.URL + "/playbyplay_%s.json"
+
+	pbp, err := c.FetchPlayByPlay(context.Background(), "0022300061")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a := pbp.Game.Actions[0]
+	if home, away := a.Score(); home != 3 || away != 0 {
+		t.Fatalf("unexpected score %d-%d", home, away)
+	}
+	if x, _, ok := a.Coordinates(); !ok || x != 12.5 {
+		t.Fatalf("expected coordinates, got %v %v", x, ok)
+	}
+	if a.ClockDisplay() != "11:42" {
+		t.Fatalf("unexpected clock %q", a.ClockDisplay())
+	}
+}
@@ -0,0 +1,98 @@
+package nba
+
+import "time"
+
+// Preview is the lead-up to a game that hasn't tipped off.
+type Preview struct {
+	Game Game `json:"game"`
+	// AwayRest and HomeRest are each team's days off before the game, 0 on
+	// the second night of a back-to-back. They're nil for a team's first
+	// game of the season.
+	AwayRest *int `json:"away_rest_days,omitempty"`
+	HomeRest *int `json:"home_rest_days,omitempty"`
+	// LastMeeting is the teams' most recent final this season, from the
+	// point of view of the team the preview is for.
+	LastMeeting *Meeting `json:"last_meeting,omitempty"`
+}
+
+// Preview builds the preview of game for team. game is usually the
+// scoreboard's copy, which has the latest status and records; broadcasters
+// come from the schedule's copy when the scoreboard has none.
+func (s *LeagueScheduleResponse) Preview(game Game, team *Franchise) Preview {
+	if scheduled, ok := s.Game(game.ID); ok && broadcastersEmpty(game.Broadcasters) {
+		game.Broadcasters = scheduled.Broadcasters
+	}
+
+	p := Preview{Game: game}
+	if away := TeamByTricode(game.AwayTeam.Tricode); away != nil {
+		p.AwayRest = s.restDays(away, game.ID)
+	}
+	if home := TeamByTricode(game.HomeTeam.Tricode); home != nil {
+		p.HomeRest = s.restDays(home, game.ID)
+	}
+
+	opponent := TeamByTricode(game.HomeTeam.Tricode)
+	if team.Matches(game.HomeTeam) {
+		opponent = TeamByTricode(game.AwayTeam.Tricode)
+	}
+	if opponent == nil {
+		return p
+	}
+	meetings := s.HeadToHead(team, opponent).Meetings
+	for i := len(meetings) - 1; i >= 0; i-- {
+		if meetings[i].Winner != "" {
+			last := meetings[i]
+			p.LastMeeting = &last
+			break
+		}
+	}
+	return p
+}
+
+// restDays returns the days team had off before the game with id, going by
+// the schedule's game dates, or nil when it's the team's first game.
+// Preseason games are skipped.
+func (s *LeagueScheduleResponse) restDays(team *Franchise, id string) *int {
+	var previous time.Time
+	for _, gameDate := range s.LeagueSchedule.GameDates {
+		day, ok := parseScheduleDate(gameDate.GameDate)
+		for _, g := range gameDate.Games {
+			if g.ID == id {
+				if !ok || previous.IsZero() {
+					return nil
+				}
+				rest := int(day.Sub(previous).Hours()/24) - 1
+				return &rest
+			}
+			if ok && !g.IsPreseason() && gameInvolves(g, team) {
+				previous = day
+			}
+		}
+	}
+	return nil
+}
+
+func broadcastersEmpty(b Broadcasters) bool {
+	return len(b.National) == 0 && len(b.HomeTV) == 0 && len(b.AwayTV) == 0
+}
+
+// OffDay is what a team has around a day it doesn't play.
+type OffDay struct {
+	Team string `json:"team"`
+	// Last is the team's most recent final and Next its next game still to
+	// be played; either is nil when there isn't one.
+	Last *Game `json:"last_game,omitempty"`
+	Next *Game `json:"next_game,omitempty"`
+}
+
+// OffDay returns team's last final and next game as of now.
+func (s *LeagueScheduleResponse) OffDay(team *Franchise, now time.Time) OffDay {
+	o := OffDay{Team: team.Tricode}
+	if recent, err := s.TeamSchedule(team, "recent", 1, now); err == nil && len(recent) > 0 {
+		o.Last = &recent[0]
+	}
+	if upcoming, err := s.TeamSchedule(team, "upcoming", 1, now); err == nil && len(upcoming) > 0 {
+		o.Next = &upcoming[0]
+	}
+	return o
+}
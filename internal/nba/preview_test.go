@@ -0,0 +1,71 @@
+package nba_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+const previewSchedule = `{"leagueSchedule":{"gameDates":[
+	{"gameDate":"10/15/2024 00:00:00","games":[
+		{"gameId":"0012400080","gameStatus":3,"homeTeam":{"teamTricode":"BOS","score":100},"awayTeam":{"teamTricode":"NYK","score":99}}]},
+	{"gameDate":"10/22/2024 00:00:00","games":[
+		{"gameId":"0022400061","gameStatus":3,"gameDateTimeUTC":"2024-10-22T23:30:00Z","homeTeam":{"teamTricode":"BOS","score":132},"awayTeam":{"teamTricode":"NYK","score":109}}]},
+	{"gameDate":"10/25/2024 00:00:00","games":[
+		{"gameId":"0022400090","gameStatus":3,"gameDateTimeUTC":"2024-10-25T23:30:00Z","homeTeam":{"teamTricode":"BOS","score":122},"awayTeam":{"teamTricode":"DET","score":102}}]},
+	{"gameDate":"10/26/2024 00:00:00","games":[
+		{"gameId":"0022400100","gameStatus":1,"gameDateTimeUTC":"2024-10-26T23:30:00Z","homeTeam":{"teamTricode":"NYK"},"awayTeam":{"teamTricode":"BOS"},
+		 "broadcasters":{"nationalBroadcasters":[{"broadcasterDisplay":"ESPN"}]}}]},
+	{"gameDate":"10/30/2024 00:00:00","games":[
+		{"gameId":"0022400130","gameStatus":1,"gameDateTimeUTC":"2024-10-30T23:30:00Z","homeTeam":{"teamTricode":"BOS"},"awayTeam":{"teamTricode":"MIL"}}]}]}}`
+
+func TestPreview(t *testing.T) {
+	var schedule nba.LeagueScheduleResponse
+	if err := json.Unmarshal([]byte(previewSchedule), &schedule); err != nil {
+		t.Fatal(err)
+	}
+
+	// The scoreboard's copy of the game has no broadcasters.
+	game := nba.Game{ID: "0022400100", GameStatus: 1, GameDateTimeUTC: "2024-10-26T23:30:00Z",
+		HomeTeam: nba.Team{Tricode: "NYK"}, AwayTeam: nba.Team{Tricode: "BOS"}}
+	p := schedule.Preview(game, nba.TeamByTricode("BOS"))
+
+	if p.AwayRest == nil || *p.AwayRest != 0 {
+		t.Fatalf("BOS played the night before, got rest %v", p.AwayRest)
+	}
+	if p.HomeRest == nil || *p.HomeRest != 3 {
+		t.Fatalf("NYK last played on the 22nd, got rest %v", p.HomeRest)
+	}
+	if p.LastMeeting == nil || p.LastMeeting.Game.ID != "0022400061" || p.LastMeeting.Winner != "BOS" || p.LastMeeting.Margin != 23 {
+		t.Fatalf("unexpected last meeting %+v", p.LastMeeting)
+	}
+	if len(p.Game.Broadcasters.National) != 1 {
+		t.Fatalf("expected broadcasters from the schedule, got %+v", p.Game.Broadcasters)
+	}
+
+	// The opener has no rest to speak of, preseason or not.
+	opener, _ := schedule.Game("0022400061")
+	if p := schedule.Preview(opener, nba.TeamByTricode("NYK")); p.AwayRest != nil || p.HomeRest != nil {
+		t.Fatalf("expected no rest days for the opener, got %v %v", p.AwayRest, p.HomeRest)
+	}
+}
+
+func TestOffDay(t *testing.T) {
+	var schedule nba.LeagueScheduleResponse
+	if err := json.Unmarshal([]byte(previewSchedule), &schedule); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, 10, 26, 12, 0, 0, 0, time.UTC)
+	o := schedule.OffDay(nba.TeamByTricode("BOS"), now)
+	if o.Team != "BOS" || o.Last == nil || o.Last.ID != "0022400090" || o.Next == nil || o.Next.ID != "0022400100" {
+		t.Fatalf("unexpected off day %+v", o)
+	}
+
+	o = schedule.OffDay(nba.TeamByTricode("MIL"), now)
+	if o.Last != nil || o.Next == nil || o.Next.ID != "0022400130" {
+		t.Fatalf("unexpected off day before the first game %+v", o)
+	}
+}
@@ -0,0 +1,175 @@
+package nba
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// SeriesPosition reads a playoff game's place from its ID, which is laid
+// out as 004, the season's two digits, 00, then one digit each for the
+// round (1-4), the series within the round and the game number (1-7). ok is
+// false for other games.
+func (g Game) SeriesPosition() (round, series, game int, ok bool) {
+	if !g.IsPlayoffs() || len(g.ID) != 10 {
+		return 0, 0, 0, false
+	}
+	digits := [3]int{}
+	for i, c := range g.ID[7:] {
+		if c < '0' || c > '9' {
+			return 0, 0, 0, false
+		}
+		digits[i] = int(c - '0')
+	}
+	return digits[0], digits[1], digits[2], true
+}
+
+// SeriesLabel returns a playoff game's place in its series, like
+// "Game 5 — BOS leads 3-2", or "" outside the playoffs. The game number
+// comes from the ID when the feed leaves it out.
+func (g Game) SeriesLabel() string {
+	number := g.SeriesGameNumber
+	if number == "" {
+		if _, _, game, ok := g.SeriesPosition(); ok {
+			number = "Game " + strconv.Itoa(game)
+		}
+	}
+	switch {
+	case number == "":
+		return g.SeriesText
+	case g.SeriesText == "":
+		return number
+	}
+	return number + " — " + g.SeriesText
+}
+
+// SeriesTeam is one side of a playoff series.
+type SeriesTeam struct {
+	Tricode string `json:"team"`
+	Seed    int    `json:"seed,omitempty"`
+	Wins    int    `json:"wins"`
+}
+
+// Series is a best-of-seven playoff series.
+type Series struct {
+	Round int `json:"round"`
+	// Number tells series in the same round apart; it's the second to last
+	// digit of the game IDs.
+	Number     int    `json:"number"`
+	Conference string `json:"conference,omitempty"` // empty for the Finals
+	// Top hosted game 1, so it's the higher seed.
+	Top    SeriesTeam `json:"top"`
+	Bottom SeriesTeam `json:"bottom"`
+	Games  []Game     `json:"games"`
+	Winner string     `json:"winner,omitempty"`
+}
+
+// SeriesWins is how many games it takes to win a series.
+const SeriesWins = 4
+
+// Status returns the state of the series, like "BOS leads 3-2",
+// "Series tied 2-2" or "BOS wins 4-1", or "" before game 1.
+func (s Series) Status() string {
+	lead, trail := s.Top, s.Bottom
+	if trail.Wins > lead.Wins {
+		lead, trail = trail, lead
+	}
+	switch {
+	case lead.Wins == 0:
+		return ""
+	case s.Winner != "":
+		return fmt.Sprintf("%s wins %d-%d", lead.Tricode, lead.Wins, trail.Wins)
+	case lead.Wins == trail.Wins:
+		return fmt.Sprintf("Series tied %d-%d", lead.Wins, trail.Wins)
+	}
+	return fmt.Sprintf("%s leads %d-%d", lead.Tricode, lead.Wins, trail.Wins)
+}
+
+// Has reports whether tricode plays in the series.
+func (s Series) Has(tricode string) bool {
+	return tricode != "" && (s.Top.Tricode == tricode || s.Bottom.Tricode == tricode)
+}
+
+// PlayoffSeries groups the schedule's playoff games into series, ordered by
+// round and then series number. Games whose teams aren't decided yet are
+// left out.
+func (s *LeagueScheduleResponse) PlayoffSeries() []Series {
+	index := map[[2]int]int{}
+	var series []Series
+	for _, gameDate := range s.LeagueSchedule.GameDates {
+		for _, g := range gameDate.Games {
+			round, number, _, ok := g.SeriesPosition()
+			if !ok || g.HomeTeam.Tricode == "" || g.AwayTeam.Tricode == "" {
+				continue
+			}
+			key := [2]int{round, number}
+			i, ok := index[key]
+			if !ok {
+				i = len(series)
+				index[key] = i
+				series = append(series, Series{Round: round, Number: number})
+			}
+			series[i].Games = append(series[i].Games, g)
+		}
+	}
+
+	for i := range series {
+		series[i].finish()
+	}
+	sort.Slice(series, func(i, j int) bool {
+		if series[i].Round != series[j].Round {
+			return series[i].Round < series[j].Round
+		}
+		return series[i].Number < series[j].Number
+	})
+	return series
+}
+
+// finish orders the series' games, fills in the teams and tallies the
+// wins.
+func (s *Series) finish() {
+	sort.SliceStable(s.Games, func(i, j int) bool {
+		_, _, a, _ := s.Games[i].SeriesPosition()
+		_, _, b, _ := s.Games[j].SeriesPosition()
+		return a < b
+	})
+
+	first := s.Games[0]
+	s.Top = SeriesTeam{Tricode: first.HomeTeam.Tricode, Seed: first.HomeTeam.Seed}
+	s.Bottom = SeriesTeam{Tricode: first.AwayTeam.Tricode, Seed: first.AwayTeam.Seed}
+	if s.Round < 4 {
+		if f := TeamByTricode(s.Top.Tricode); f != nil {
+			s.Conference = f.Conference
+		}
+	}
+
+	for _, g := range s.Games {
+		for _, t := range []Team{g.HomeTeam, g.AwayTeam} {
+			if t.Tricode == s.Top.Tricode && s.Top.Seed == 0 {
+				s.Top.Seed = t.Seed
+			}
+			if t.Tricode == s.Bottom.Tricode && s.Bottom.Seed == 0 {
+				s.Bottom.Seed = t.Seed
+			}
+		}
+		if !g.State().IsFinal() {
+			continue
+		}
+		winner := g.HomeTeam.Tricode
+		if g.AwayTeam.Score > g.HomeTeam.Score {
+			winner = g.AwayTeam.Tricode
+		}
+		switch winner {
+		case s.Top.Tricode:
+			s.Top.Wins++
+		case s.Bottom.Tricode:
+			s.Bottom.Wins++
+		}
+	}
+	switch {
+	case s.Top.Wins >= SeriesWins:
+		s.Winner = s.Top.Tricode
+	case s.Bottom.Wins >= SeriesWins:
+		s.Winner = s.Bottom.Tricode
+	}
+}
@@ -0,0 +1,69 @@
+package nba_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+const playoffSchedule = `{"leagueSchedule":{"gameDates":[
+	{"gameDate":"04/15/2025 00:00:00","games":[
+		{"gameId":"0052400101","gameStatus":3,"homeTeam":{"teamTricode":"ORL","score":120},"awayTeam":{"teamTricode":"ATL","score":95}}]},
+	{"gameDate":"04/19/2025 00:00:00","games":[
+		{"gameId":"0042400101","gameStatus":3,"ifNecessary":"false","homeTeam":{"teamTricode":"CLE","seed":1,"score":121},"awayTeam":{"teamTricode":"MIA","seed":8,"score":100}},
+		{"gameId":"0042400111","gameStatus":3,"ifNecessary":false,"homeTeam":{"teamTricode":"BOS","seed":2,"score":103},"awayTeam":{"teamTricode":"ORL","seed":7,"score":86}}]},
+	{"gameDate":"04/21/2025 00:00:00","games":[
+		{"gameId":"0042400102","gameStatus":3,"homeTeam":{"teamTricode":"CLE","score":121},"awayTeam":{"teamTricode":"MIA","score":112}},
+		{"gameId":"0042400112","gameStatus":3,"seriesGameNumber":"Game 2","seriesText":"Series tied 1-1","homeTeam":{"teamTricode":"BOS","score":100},"awayTeam":{"teamTricode":"ORL","score":109}}]},
+	{"gameDate":"04/23/2025 00:00:00","games":[
+		{"gameId":"0042400113","gameStatus":1,"ifNecessary":"true","homeTeam":{"teamTricode":"ORL"},"awayTeam":{"teamTricode":"BOS"}},
+		{"gameId":"0042400201","gameStatus":1,"homeTeam":{"teamTricode":""},"awayTeam":{"teamTricode":""}}]}]}}`
+
+func TestPlayoffSeries(t *testing.T) {
+	var schedule nba.LeagueScheduleResponse
+	if err := json.Unmarshal([]byte(playoffSchedule), &schedule); err != nil {
+		t.Fatal(err)
+	}
+
+	series := schedule.PlayoffSeries()
+	if len(series) != 2 {
+		t.Fatalf("expected two first round series, got %+v", series)
+	}
+	cle, bos := series[0], series[1]
+	if cle.Round != 1 || cle.Number != 0 || cle.Conference != "East" || cle.Top.Tricode != "CLE" || cle.Bottom.Seed != 8 {
+		t.Fatalf("unexpected series %+v", cle)
+	}
+	if cle.Status() != "CLE leads 2-0" || bos.Status() != "Series tied 1-1" || len(bos.Games) != 3 {
+		t.Fatalf("statuses %q, %q", cle.Status(), bos.Status())
+	}
+	if !bos.Games[2].IfNecessary || bos.Games[0].IfNecessary {
+		t.Fatal("ifNecessary not decoded from either form")
+	}
+}
+
+func TestSeriesStatus(t *testing.T) {
+	s := nba.Series{Top: nba.SeriesTeam{Tricode: "BOS", Wins: 1}, Bottom: nba.SeriesTeam{Tricode: "NYK", Wins: 4}, Winner: "NYK"}
+	if got := s.Status(); got != "NYK wins 4-1" {
+		t.Fatalf("got %q", got)
+	}
+	if got := (nba.Series{}).Status(); got != "" {
+		t.Fatalf("got %q before game 1", got)
+	}
+}
+
+func TestGame_SeriesLabel(t *testing.T) {
+	tests := []struct {
+		game nba.Game
+		want string
+	}{
+		{nba.Game{ID: "0042400405", SeriesGameNumber: "Game 5", SeriesText: "BOS leads 3-2"}, "Game 5 — BOS leads 3-2"},
+		{nba.Game{ID: "0042400405"}, "Game 5"},
+		{nba.Game{ID: "0022400405"}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.game.SeriesLabel(); got != tt.want {
+			t.Errorf("SeriesLabel(%s) = %q, want %q", tt.game.ID, got, tt.want)
+		}
+	}
+}
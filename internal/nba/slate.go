@@ -0,0 +1,55 @@
+package nba
+
+import (
+	"context"
+	"time"
+)
+
+// scheduleDateLayouts are the formats seen in the schedule's gameDate field.
+var scheduleDateLayouts = []string{"01/02/2006 15:04:05", "2006-01-02"}
+
+// parseScheduleDate parses a schedule gameDate such as "10/22/2024 00:00:00".
+func parseScheduleDate(s string) (time.Time, bool) {
+	for _, layout := range scheduleDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// FetchGamesOn returns every game that tips off on the calendar day of day,
+// in day's location. Games without a known start time fall back to the
+// schedule's own date, which follows US Eastern time.
+func (c *Client) FetchGamesOn(ctx context.Context, day time.Time) ([]Game, error) {
+	schedule, err := c.FetchLeagueSchedule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return schedule.GamesOn(day), nil
+}
+
+// GamesOn is FetchGamesOn for a schedule that has already been fetched.
+func (s *LeagueScheduleResponse) GamesOn(day time.Time) []Game {
+	loc := day.Location()
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 1)
+	want := start.Format("2006-01-02")
+
+	var games []Game
+	for _, gameDate := range s.LeagueSchedule.GameDates {
+		scheduled, hasDate := parseScheduleDate(gameDate.GameDate)
+		for _, g := range gameDate.Games {
+			if tip, err := time.Parse(time.RFC3339, g.StartTimeUTC()); err == nil {
+				if !tip.Before(start) && tip.Before(end) {
+					games = append(games, g)
+				}
+				continue
+			}
+			if hasDate && scheduled.Format("2006-01-02") == want {
+				games = append(games, g)
+			}
+		}
+	}
+	return games
+}
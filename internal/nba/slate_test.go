@@ -0,0 +1,83 @@
+package nba_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestFetchGamesOn_MatchesScheduleDate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"leagueSchedule":{"gameDates":[
+			{"gameDate":"10/22/2024 00:00:00","games":[{"gameId":"0022400061","gameStatus":3},{"gameId":"0022400062","gameStatus":3}]},
+			{"gameDate":"10/23/2024 00:00:00","games":[{"gameId":"0022400063","gameStatus":1,
+				"broadcasters":{"nationalBroadcasters":[{"broadcasterDisplay":"ESPN"}]}}]}]}}`))
+	}))
+	defer server.Close()
+
+	c := nba.NewClient()
+	c.LeagueScheduleURL = server.URL
+
+	games, err := c.FetchGamesOn(context.Background(), time.Date(2024, 10, 23, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(games) != 1 || games[0].ID != "0022400063" {
+		t.Fatalf("expected only the Oct 23 game, got %+v", games)
+	}
+	if b := games[0].Broadcasters.National; len(b) != 1 || b[0].Display != "ESPN" {
+		t.Fatalf("expected national broadcaster, got %+v", b)
+	}
+}
+
+func TestGame_StartTimeUTCPrefersDateTime(t *testing.T) {
+	g := nba.Game{GameTimeUTC: "1900-01-01T23:30:00Z", GameDateTimeUTC: "2024-10-22T23:30:00Z"}
+	if g.StartTimeUTC() != "2024-10-22T23:30:00Z" {
+		t.Fatalf("unexpected start time %q", g.StartTimeUTC())
+	}
+}
+
+func TestFetchGamesOn_UsesDayLocation(t *testing.T) {
+	// A 10:30 PM ET tip is already the next day in Paris.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"leagueSchedule":{"gameDates":[
+			{"gameDate":"10/22/2024 00:00:00","games":[{"gameId":"0022400062","gameStatus":1,"gameDateTimeUTC":"2024-10-23T02:30:00Z"}]}]}}`))
+	}))
+	defer server.Close()
+
+	c := nba.NewClient()
+	c.LeagueScheduleURL = server.URL
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	games, err := c.FetchGamesOn(context.Background(), time.Date(2024, 10, 23, 0, 0, 0, 0, paris))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(games) != 1 {
+		t.Fatalf("expected the late ET game on Oct 23 in Paris, got %d", len(games))
+	}
+
+	ny, _ := time.LoadLocation("America/New_York")
+	games, _ = c.FetchGamesOn(context.Background(), time.Date(2024, 10, 23, 0, 0, 0, 0, ny))
+	if len(games) != 0 {
+		t.Fatalf("expected no games on Oct 23 in New York, got %d", len(games))
+	}
+}
+
+func TestGame_ArenaLocation(t *testing.T) {
+	g := nba.Game{HomeTeam: nba.Team{Tricode: "LAL"}}
+	if loc := g.ArenaLocation(); loc == nil || loc.String() != "America/Los_Angeles" {
+		t.Fatalf("expected Lakers home zone, got %v", loc)
+	}
+	g.ArenaCity = "Paris"
+	if loc := g.ArenaLocation(); loc == nil || loc.String() != "Europe/Paris" {
+		t.Fatalf("expected neutral site zone, got %v", loc)
+	}
+}
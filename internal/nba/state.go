@@ -0,0 +1,110 @@
+package nba
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// The feeds' gameStatus codes.
+const (
+	StatusScheduled = 1
+	StatusLive      = 2
+	StatusFinal     = 3
+)
+
+// GameState is where a game stands. The feeds only have three status
+// codes, so postponed, cancelled and suspended games, and games without a
+// tip-off time yet, are recognized from the status text and the schedule's
+// postponedStatus.
+type GameState int
+
+const (
+	Scheduled GameState = iota
+	TimeTBD
+	Live
+	Final
+	Postponed
+	Cancelled
+	Suspended
+)
+
+var gameStateNames = map[GameState]string{
+	Scheduled: "Scheduled",
+	TimeTBD:   "Time TBD",
+	Live:      "Live",
+	Final:     "Final",
+	Postponed: "Postponed",
+	Cancelled: "Cancelled",
+	Suspended: "Suspended",
+}
+
+// String returns e.g. "Scheduled", "Time TBD" or "Postponed".
+func (s GameState) String() string {
+	return gameStateNames[s]
+}
+
+// IsUpcoming reports whether the game is still to be played as scheduled,
+// with or without a tip-off time.
+func (s GameState) IsUpcoming() bool { return s == Scheduled || s == TimeTBD }
+
+// IsTimeTBD reports whether the game is on the schedule without a tip-off
+// time.
+func (s GameState) IsTimeTBD() bool { return s == TimeTBD }
+
+// IsLive reports whether the game is being played.
+func (s GameState) IsLive() bool { return s == Live }
+
+// IsFinal reports whether the game is over.
+func (s GameState) IsFinal() bool { return s == Final }
+
+// IsPostponed reports whether the game was postponed, to be made up later.
+func (s GameState) IsPostponed() bool { return s == Postponed }
+
+// IsOff reports whether the game won't go ahead as scheduled: postponed,
+// cancelled or suspended.
+func (s GameState) IsOff() bool { return s == Postponed || s == Cancelled || s == Suspended }
+
+// HasScore reports whether the game's score means anything yet.
+func (s GameState) HasScore() bool { return s == Live || s == Final || s == Suspended }
+
+// IsDone reports whether nothing more will happen in the game today.
+func (s GameState) IsDone() bool { return s == Final || s == Postponed || s == Cancelled }
+
+// State works out g's state from its status code, status text and
+// postponedStatus.
+func (g Game) State() GameState {
+	text := strings.ToUpper(strings.TrimSpace(g.GameStatusText))
+	switch {
+	case g.PostponedStatus == "P", strings.Contains(text, "PPD"), strings.Contains(text, "POSTPONED"):
+		return Postponed
+	case strings.Contains(text, "CANCEL"), strings.Contains(text, "CNCL"):
+		return Cancelled
+	case strings.Contains(text, "SUSPENDED"):
+		return Suspended
+	}
+
+	switch g.GameStatus {
+	case StatusLive:
+		return Live
+	case StatusFinal:
+		return Final
+	}
+	if strings.Contains(text, "TBD") {
+		return TimeTBD
+	}
+	if _, err := time.Parse(time.RFC3339, g.StartTimeUTC()); err != nil {
+		return TimeTBD
+	}
+	return Scheduled
+}
+
+// MarshalJSON writes the feed's fields plus state, State as text, so JSON
+// and YAML output say what CSV's state column does.
+func (g Game) MarshalJSON() ([]byte, error) {
+	type game Game // without this method
+	return json.Marshal(struct {
+		game
+		State string `json:"state"`
+	}{game(g), g.State().String()})
+}
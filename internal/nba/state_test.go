@@ -0,0 +1,76 @@
+package nba_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestGame_State(t *testing.T) {
+	const tip = "2025-01-10T00:30:00Z"
+	tests := []struct {
+		game nba.Game
+		want nba.GameState
+	}{
+		{nba.Game{GameStatus: 1, GameStatusText: "7:30 pm ET", GameDateTimeUTC: tip}, nba.Scheduled},
+		{nba.Game{GameStatus: 1, GameStatusText: "TBD", GameDateTimeUTC: tip}, nba.TimeTBD},
+		{nba.Game{GameStatus: 1}, nba.TimeTBD},
+		{nba.Game{GameStatus: 2, GameStatusText: "Q3 5:21"}, nba.Live},
+		{nba.Game{GameStatus: 3, GameStatusText: "Final/OT"}, nba.Final},
+		{nba.Game{GameStatus: 1, GameStatusText: "PPD", GameDateTimeUTC: tip}, nba.Postponed},
+		{nba.Game{GameStatus: 1, PostponedStatus: "P", GameDateTimeUTC: tip}, nba.Postponed},
+		{nba.Game{GameStatus: 1, GameStatusText: "Cancelled"}, nba.Cancelled},
+		{nba.Game{GameStatus: 2, GameStatusText: "Suspended"}, nba.Suspended},
+	}
+	for _, tt := range tests {
+		if got := tt.game.State(); got != tt.want {
+			t.Errorf("State(%d, %q, %q) = %v, want %v", tt.game.GameStatus, tt.game.GameStatusText, tt.game.PostponedStatus, got, tt.want)
+		}
+	}
+}
+
+func TestGameState_Methods(t *testing.T) {
+	if !nba.TimeTBD.IsUpcoming() || !nba.TimeTBD.IsTimeTBD() || nba.TimeTBD.HasScore() {
+		t.Error("a game without a time is upcoming and has no score")
+	}
+	if !nba.Postponed.IsPostponed() || !nba.Postponed.IsOff() || !nba.Postponed.IsDone() || nba.Postponed.IsUpcoming() {
+		t.Error("a postponed game is off and done for the day")
+	}
+	if !nba.Suspended.HasScore() || nba.Suspended.IsDone() || !nba.Live.IsLive() || !nba.Final.IsFinal() {
+		t.Error("unexpected live, final or suspended flags")
+	}
+	if nba.Postponed.String() != "Postponed" {
+		t.Errorf("String() = %q", nba.Postponed.String())
+	}
+}
+
+func TestGame_MarshalJSONAddsState(t *testing.T) {
+	data, err := json.Marshal(nba.Game{ID: "0022400061", GameStatus: 1, GameStatusText: "PPD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["gameId"] != "0022400061" || got["state"] != "Postponed" {
+		t.Fatalf("unexpected JSON %s", data)
+	}
+
+	var back nba.Game
+	if err := json.Unmarshal(data, &back); err != nil || back.ID != "0022400061" {
+		t.Fatalf("the JSON should still decode as a game: %+v %v", back, err)
+	}
+}
+
+func TestFlag(t *testing.T) {
+	var teams []nba.Team
+	err := json.Unmarshal([]byte(`[{"inBonus":"1"},{"inBonus":"0"},{"inBonus":null},{"inBonus":true},{}]`), &teams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !teams[0].InBonus || teams[1].InBonus || teams[2].InBonus || !teams[3].InBonus || teams[4].InBonus {
+		t.Fatalf("unexpected flags %+v", teams)
+	}
+}
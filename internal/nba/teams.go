@@ -0,0 +1,202 @@
+package nba
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownTeam indicates a team query matched none of the 30 franchises.
+var ErrUnknownTeam = errors.New("unknown team")
+
+// Franchise describes one of the league's 30 teams.
+type Franchise struct {
+	ID         int      `json:"team_id"`
+	Tricode    string   `json:"tricode"`
+	City       string   `json:"city"`
+	Nickname   string   `json:"nickname"`
+	Conference string   `json:"conference"` // "East" or "West"
+	Division   string   `json:"division"`
+	Aliases    []string `json:"aliases,omitempty"`
+	TimeZone   string   `json:"time_zone"` // IANA name of the home arena's zone
+}
+
+// FullName returns e.g. "Boston Celtics".
+func (f Franchise) FullName() string {
+	return f.City + " " + f.Nickname
+}
+
+// Matches reports whether t (a team as it appears in a game) is this franchise.
+func (f Franchise) Matches(t Team) bool {
+	if t.ID != 0 {
+		return t.ID == f.ID
+	}
+	return strings.EqualFold(t.Tricode, f.Tricode)
+}
+
+// Teams is the registry of all 30 franchises.
+var Teams = []Franchise{
+	{1610612737, "ATL", "Atlanta", "Hawks", "East", "Southeast", []string{"atl hawks"}, "America/New_York"},
+	{1610612738, "BOS", "Boston", "Celtics", "East", "Atlantic", []string{"celts"}, "America/New_York"},
+	{1610612751, "BKN", "Brooklyn", "Nets", "East", "Atlantic", []string{"bkn nets", "bk", "brk"}, "America/New_York"},
+	{1610612766, "CHA", "Charlotte", "Hornets", "East", "Southeast", []string{"cho", "buzz city"}, "America/New_York"},
+	{1610612741, "CHI", "Chicago", "Bulls", "East", "Central", nil, "America/Chicago"},
+	{1610612739, "CLE", "Cleveland", "Cavaliers", "East", "Central", []string{"cavs"}, "America/New_York"},
+	{1610612742, "DAL", "Dallas", "Mavericks", "West", "Southwest", []string{"mavs"}, "America/Chicago"},
+	{1610612743, "DEN", "Denver", "Nuggets", "West", "Northwest", []string{"nugs"}, "America/Denver"},
+	{1610612765, "DET", "Detroit", "Pistons", "East", "Central", nil, "America/Detroit"},
+	{1610612744, "GSW", "Golden State", "Warriors", "West", "Pacific", []string{"gs", "dubs"}, "America/Los_Angeles"},
+	{1610612745, "HOU", "Houston", "Rockets", "West", "Southwest", nil, "America/Chicago"},
+	{1610612754, "IND", "Indiana", "Pacers", "East", "Central", nil, "America/Indiana/Indianapolis"},
+	{1610612746, "LAC", "LA", "Clippers", "West", "Pacific", []string{"clips", "los angeles clippers", "los angeles"}, "America/Los_Angeles"},
+	{1610612747, "LAL", "Los Angeles", "Lakers", "West", "Pacific", []string{"la lakers", "la"}, "America/Los_Angeles"},
+	{1610612763, "MEM", "Memphis", "Grizzlies", "West", "Southwest", []string{"grizz"}, "America/Chicago"},
+	{1610612748, "MIA", "Miami", "Heat", "East", "Southeast", nil, "America/New_York"},
+	{1610612749, "MIL", "Milwaukee", "Bucks", "East", "Central", nil, "America/Chicago"},
+	{1610612750, "MIN", "Minnesota", "Timberwolves", "West", "Northwest", []string{"wolves", "twolves"}, "America/Chicago"},
+	{1610612740, "NOP", "New Orleans", "Pelicans", "West", "Southwest", []string{"pels", "no", "nola"}, "America/Chicago"},
+	{1610612752, "NYK", "New York", "Knicks", "East", "Atlantic", []string{"ny", "knickerbockers"}, "America/New_York"},
+	{1610612760, "OKC", "Oklahoma City", "Thunder", "West", "Northwest", nil, "America/Chicago"},
+	{1610612753, "ORL", "Orlando", "Magic", "East", "Southeast", nil, "America/New_York"},
+	{1610612755, "PHI", "Philadelphia", "76ers", "East", "Atlantic", []string{"sixers", "philly"}, "America/New_York"},
+	{1610612756, "PHX", "Phoenix", "Suns", "West", "Pacific", []string{"pho"}, "America/Phoenix"},
+	{1610612757, "POR", "Portland", "Trail Blazers", "West", "Northwest", []string{"blazers", "trailblazers", "rip city"}, "America/Los_Angeles"},
+	{1610612758, "SAC", "Sacramento", "Kings", "West", "Pacific", nil, "America/Los_Angeles"},
+	{1610612759, "SAS", "San Antonio", "Spurs", "West", "Southwest", []string{"sa"}, "America/Chicago"},
+	{1610612761, "TOR", "Toronto", "Raptors", "East", "Atlantic", []string{"raps"}, "America/Toronto"},
+	{1610612762, "UTA", "Utah", "Jazz", "West", "Northwest", []string{"uth"}, "America/Denver"},
+	{1610612764, "WAS", "Washington", "Wizards", "East", "Southeast", []string{"wiz", "wsh"}, "America/New_York"},
+}
+
+// AmbiguousTeamError is returned by Resolve when a query matches more than
+// one franchise.
+type AmbiguousTeamError struct {
+	Query      string
+	Candidates []Franchise
+}
+
+func (e *AmbiguousTeamError) Error() string {
+	names := make([]string, len(e.Candidates))
+	for i, c := range e.Candidates {
+		names[i] = fmt.Sprintf("%s (%s)", c.Tricode, c.FullName())
+	}
+	return fmt.Sprintf("%q matches several teams: %s", e.Query, strings.Join(names, ", "))
+}
+
+var (
+	teamIndexOnce sync.Once
+	teamIndex     map[string][]int // normalized key -> indexes into Teams
+)
+
+func buildTeamIndex() {
+	teamIndex = make(map[string][]int)
+	add := func(key string, i int) {
+		key = normalizeTeamQuery(key)
+		for _, existing := range teamIndex[key] {
+			if existing == i {
+				return
+			}
+		}
+		teamIndex[key] = append(teamIndex[key], i)
+	}
+	for i, f := range Teams {
+		add(f.Tricode, i)
+		add(f.City, i)
+		add(f.Nickname, i)
+		add(f.FullName(), i)
+		add(strconv.Itoa(f.ID), i)
+		for _, a := range f.Aliases {
+			add(a, i)
+		}
+	}
+}
+
+// normalizeTeamQuery lowercases s and drops punctuation so "Trail-Blazers",
+// "trail blazers" and "TRAIL BLAZERS" compare equal.
+func normalizeTeamQuery(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '_':
+			space = true
+		}
+	}
+	return b.String()
+}
+
+// Resolve returns the franchise named by query: a tricode, ID, city,
+// nickname, full name or common alias, or an unambiguous prefix of one of
+// those. It returns an *AmbiguousTeamError listing the candidates when the
+// query fits several teams, and an error wrapping ErrUnknownTeam when it
+// fits none.
+func Resolve(query string) (*Franchise, error) {
+	teamIndexOnce.Do(buildTeamIndex)
+
+	q := normalizeTeamQuery(query)
+	if q == "" {
+		return nil, fmt.Errorf("%w: empty team name", ErrUnknownTeam)
+	}
+
+	matches := teamIndex[q]
+	if len(matches) == 0 && len(q) >= 3 {
+		seen := map[int]bool{}
+		for key, idxs := range teamIndex {
+			if !strings.HasPrefix(key, q) {
+				continue
+			}
+			for _, i := range idxs {
+				if !seen[i] {
+					seen[i] = true
+					matches = append(matches, i)
+				}
+			}
+		}
+		sort.Ints(matches)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w %q", ErrUnknownTeam, query)
+	case 1:
+		f := Teams[matches[0]]
+		return &f, nil
+	}
+
+	candidates := make([]Franchise, len(matches))
+	for i, idx := range matches {
+		candidates[i] = Teams[idx]
+	}
+	return nil, &AmbiguousTeamError{Query: query, Candidates: candidates}
+}
+
+// TeamByID returns the franchise with the given team ID, or nil.
+func TeamByID(id int) *Franchise {
+	for i := range Teams {
+		if Teams[i].ID == id {
+			f := Teams[i]
+			return &f
+		}
+	}
+	return nil
+}
+
+// TeamByTricode returns the franchise with the given tricode, or nil.
+func TeamByTricode(tricode string) *Franchise {
+	for i := range Teams {
+		if strings.EqualFold(Teams[i].Tricode, tricode) {
+			f := Teams[i]
+			return &f
+		}
+	}
+	return nil
+}
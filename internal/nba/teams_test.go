@@ -0,0 +1,86 @@
+package nba_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestTeams_ThirtyUniqueFranchises(t *testing.T) {
+	if len(nba.Teams) != 30 {
+		t.Fatalf("expected 30 franchises, got %d", len(nba.Teams))
+	}
+	seen := map[string]bool{}
+	for _, f := range nba.Teams {
+		if seen[f.Tricode] {
+			t.Fatalf("duplicate tricode %s", f.Tricode)
+		}
+		seen[f.Tricode] = true
+		if f.Conference != "East" && f.Conference != "West" {
+			t.Fatalf("%s has unexpected conference %q", f.Tricode, f.Conference)
+		}
+	}
+}
+
+func TestResolve_Exact(t *testing.T) {
+	cases := map[string]string{
+		"nets":           "BKN",
+		"Hornets":        "CHA",
+		"heat":           "MIA",
+		"sixers":         "PHI",
+		"76ers":          "PHI",
+		"cavs":           "CLE",
+		"blazers":        "POR",
+		"Trail Blazers":  "POR",
+		"wolves":         "MIN",
+		"nyk":            "NYK",
+		"new york":       "NYK",
+		"Golden State":   "GSW",
+		"Boston Celtics": "BOS",
+		"1610612747":     "LAL",
+		"celt":           "BOS", // unambiguous prefix
+	}
+	for query, want := range cases {
+		f, err := nba.Resolve(query)
+		if err != nil {
+			t.Errorf("Resolve(%q) error: %v", query, err)
+			continue
+		}
+		if f.Tricode != want {
+			t.Errorf("Resolve(%q) = %s, want %s", query, f.Tricode, want)
+		}
+	}
+}
+
+func TestResolve_Ambiguous(t *testing.T) {
+	_, err := nba.Resolve("los angeles")
+	var amb *nba.AmbiguousTeamError
+	if !errors.As(err, &amb) {
+		t.Fatalf("expected AmbiguousTeamError, got %v", err)
+	}
+	if len(amb.Candidates) != 2 {
+		t.Fatalf("expected 2 candidates, got %+v", amb.Candidates)
+	}
+
+	if _, err := nba.Resolve("new"); !errors.As(err, &amb) {
+		t.Fatalf("expected prefix \"new\" to be ambiguous, got %v", err)
+	}
+}
+
+func TestResolve_Unknown(t *testing.T) {
+	if _, err := nba.Resolve("sonics"); !errors.Is(err, nba.ErrUnknownTeam) {
+		t.Fatalf("expected ErrUnknownTeam, got %v", err)
+	}
+}
+
+func TestFranchise_MatchesPrefersID(t *testing.T) {
+	nets, _ := nba.Resolve("nets")
+	hornets := nba.Team{ID: 1610612766, Name: "Hornets", Tricode: "CHA"}
+	if nets.Matches(hornets) {
+		t.Fatalf("nets should not match the Hornets")
+	}
+	if !nets.Matches(nba.Team{Tricode: "BKN"}) {
+		t.Fatalf("expected tricode match when ID is missing")
+	}
+}
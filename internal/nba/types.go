@@ -1,5 +1,11 @@
 package nba
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 type Team struct {
 	ID                int    `json:"teamId"`
 	Name              string `json:"teamName"`
@@ -9,6 +15,21 @@ type Team struct {
 	Wins              int    `json:"wins"`
 	Losses            int    `json:"losses"`
 	TimeoutsRemaining int    `json:"timeoutsRemaining,omitempty"`
+	// InBonus is set while the team's opponent is over the foul limit, so
+	// every foul on them sends this team to the line. Live feeds only.
+	InBonus Flag `json:"inBonus,omitempty"`
+	// Seed is the team's playoff seed; the schedule feed sets it for
+	// postseason games.
+	Seed int `json:"seed,omitempty"`
+	// Periods holds the per-period scores; only live feeds fill it in.
+	Periods []Period `json:"periods,omitempty"`
+}
+
+// Period is one team's score in a quarter or overtime.
+type Period struct {
+	Period int    `json:"period"`
+	Type   string `json:"periodType"` // "REGULAR" or "OVERTIME"
+	Score  int    `json:"score"`
 }
 
 type Leader struct {
@@ -28,20 +49,126 @@ type GameLeaders struct {
 	AwayLeaders Leader `json:"awayLeaders"`
 }
 
+type Broadcaster struct {
+	ID           int    `json:"broadcasterId"`
+	Display      string `json:"broadcasterDisplay"` // e.g. "ESPN", "MSG"
+	Abbreviation string `json:"broadcasterAbbreviation"`
+	Media        string `json:"broadcasterMedia,omitempty"` // "tv", "radio", "ott"
+}
+
+// Broadcasters lists who carries a game. Only the schedule feed fills it in.
+type Broadcasters struct {
+	National []Broadcaster `json:"nationalBroadcasters,omitempty"`
+	HomeTV   []Broadcaster `json:"homeTvBroadcasters,omitempty"`
+	AwayTV   []Broadcaster `json:"awayTvBroadcasters,omitempty"`
+}
+
 type Game struct {
-	ID              string      `json:"gameId"`
-	GameCode        string      `json:"gameCode"`
-	GameStatus      int         `json:"gameStatus"`
-	GameStatusText  string      `json:"gameStatusText"` // e.g. "In Progress", "Final", "Scheduled"
-	Period          int         `json:"period,omitempty"`
-	GameClock       string      `json:"gameClock,omitempty"`
-	GameTimeUTC     string      `json:"gameTimeUTC"`
-	GameDateTimeUTC string      `json:"gameDateTimeUTC"`
-	GameDateTimeEst string      `json:"gameDateTimeEst"`
-	GameEt          string      `json:"gameEt"`
-	HomeTeam        Team        `json:"homeTeam"`
-	AwayTeam        Team        `json:"awayTeam"`
-	GameLeaders     GameLeaders `json:"gameLeaders,omitempty"`
+	ID              string       `json:"gameId"`
+	GameCode        string       `json:"gameCode"`
+	GameStatus      int          `json:"gameStatus"`                // StatusScheduled, StatusLive or StatusFinal; see State
+	GameStatusText  string       `json:"gameStatusText"`            // e.g. "In Progress", "Final", "Scheduled"
+	PostponedStatus string       `json:"postponedStatus,omitempty"` // "P" when postponed; schedule feed only
+	Period          int          `json:"period,omitempty"`
+	GameClock       GameClock    `json:"gameClock,omitempty"`
+	GameTimeUTC     string       `json:"gameTimeUTC"`
+	GameDateTimeUTC string       `json:"gameDateTimeUTC"`
+	GameDateTimeEst string       `json:"gameDateTimeEst"`
+	GameEt          string       `json:"gameEt"`
+	HomeTeam        Team         `json:"homeTeam"`
+	AwayTeam        Team         `json:"awayTeam"`
+	GameLeaders     GameLeaders  `json:"gameLeaders,omitempty"`
+	Broadcasters    Broadcasters `json:"broadcasters,omitempty"`
+	ArenaName       string       `json:"arenaName,omitempty"`
+	ArenaCity       string       `json:"arenaCity,omitempty"`
+	ArenaState      string       `json:"arenaState,omitempty"`
+
+	// Playoff games carry their place in the series; these are empty
+	// otherwise.
+	SeriesGameNumber string `json:"seriesGameNumber,omitempty"` // e.g. "Game 5"
+	SeriesText       string `json:"seriesText,omitempty"`       // e.g. "BOS leads 3-2"
+	SeriesConference string `json:"seriesConference,omitempty"`
+	PoRoundDesc      string `json:"poRoundDesc,omitempty"` // e.g. "East First Round"
+	IfNecessary      Flag   `json:"ifNecessary,omitempty"`
+}
+
+// StartTimeUTC returns the tip-off timestamp in RFC 3339. The schedule feed's
+// gameTimeUTC carries a placeholder date, so gameDateTimeUTC wins when set.
+func (g Game) StartTimeUTC() string {
+	if g.GameDateTimeUTC != "" {
+		return g.GameDateTimeUTC
+	}
+	return g.GameTimeUTC
+}
+
+// IsPreseason reports whether g is an exhibition game, which doesn't count
+// in any record. Preseason game IDs start with "001".
+func (g Game) IsPreseason() bool {
+	return strings.HasPrefix(g.ID, "001")
+}
+
+// IsRegularSeason reports whether g counts toward the standings. Regular
+// season game IDs start with "002"; NBA Cup group and knockout games before
+// the final are among them.
+func (g Game) IsRegularSeason() bool {
+	return strings.HasPrefix(g.ID, "002")
+}
+
+// IsPlayoffs reports whether g is a postseason game, not counting the
+// play-in. Playoff game IDs start with "004"; see SeriesPosition.
+func (g Game) IsPlayoffs() bool {
+	return strings.HasPrefix(g.ID, "004")
+}
+
+// IsPlayIn reports whether g is a play-in tournament game. Play-in game IDs
+// start with "005".
+func (g Game) IsPlayIn() bool {
+	return strings.HasPrefix(g.ID, "005")
+}
+
+// Flag is a boolean the feeds send as JSON true/false, as the strings
+// "true"/"false", or as "1"/"0".
+type Flag bool
+
+// UnmarshalJSON accepts all the forms above, and null.
+func (f *Flag) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "true", `"true"`, "1", `"1"`:
+		*f = true
+	case "false", `"false"`, "0", `"0"`, `""`, "null":
+		*f = false
+	default:
+		return fmt.Errorf("invalid flag %s", data)
+	}
+	return nil
+}
+
+// Record is a win-loss record.
+type Record struct {
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+}
+
+// String returns e.g. "3-1".
+func (r Record) String() string {
+	return fmt.Sprintf("%d-%d", r.Wins, r.Losses)
+}
+
+// Pct returns the winning percentage, 0 before any games.
+func (r Record) Pct() float64 {
+	if r.Wins+r.Losses == 0 {
+		return 0
+	}
+	return float64(r.Wins) / float64(r.Wins+r.Losses)
+}
+
+// Add counts one game, won or lost.
+func (r *Record) Add(won bool) {
+	if won {
+		r.Wins++
+	} else {
+		r.Losses++
+	}
 }
 
 type PlayerStats struct {
@@ -52,8 +179,52 @@ type PlayerStats struct {
 	Assists    int    `json:"assists"`
 }
 
+// StaleAfter is how long the scoreboard can go without an update while a
+// game is live before it counts as stale. The CDN rewrites it every few
+// seconds during play.
+const StaleAfter = 2 * time.Minute
+
 type GameSummary struct {
 	Game          Game          `json:"game"`
 	TopPerformers []PlayerStats `json:"top_performers,omitempty"`
-	LastUpdated   string        `json:"last_updated"`
+	// LastUpdated is when the feed behind the summary was last updated, in
+	// RFC 3339, and Age how many seconds old that was when the summary was
+	// built. Stale is set when a live game's feed is older than StaleAfter.
+	LastUpdated string `json:"last_updated"`
+	Age         int    `json:"age_seconds"`
+	Stale       bool   `json:"stale"`
+}
+
+// NewGameSummary builds the catch-up view of a game from its scoreboard
+// entry, with each team's leader as a top performer.
+func NewGameSummary(game Game) GameSummary {
+	return GameSummary{
+		Game: game,
+		TopPerformers: []PlayerStats{
+			leaderStats(game.GameLeaders.HomeLeaders),
+			leaderStats(game.GameLeaders.AwayLeaders),
+		},
+	}
+}
+
+// SetUpdated records that the feed behind s was last updated at updated,
+// as of now.
+func (s *GameSummary) SetUpdated(updated, now time.Time) {
+	age := now.Sub(updated)
+	if age < 0 {
+		age = 0
+	}
+	s.LastUpdated = updated.UTC().Format(time.RFC3339)
+	s.Age = int(age.Seconds())
+	s.Stale = s.Game.State().IsLive() && age > StaleAfter
+}
+
+func leaderStats(l Leader) PlayerStats {
+	return PlayerStats{
+		PlayerName: l.Name,
+		TeamCode:   l.TeamTricode,
+		Points:     l.Points,
+		Rebounds:   l.Rebounds,
+		Assists:    l.Assists,
+	}
 }
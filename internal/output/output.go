@@ -0,0 +1,152 @@
+// Package output renders command results as JSON, YAML, CSV or TSV for
+// scripts. The text format is handled by internal/util.
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format selected with --output.
+type Format string
+
+const (
+	Text Format = "text"
+	JSON Format = "json"
+	YAML Format = "yaml"
+	CSV  Format = "csv"
+	TSV  Format = "tsv"
+)
+
+// Formats lists every supported format, text first.
+var Formats = []Format{Text, JSON, YAML, CSV, TSV}
+
+// ParseFormat parses a --output value. Empty means Text.
+func ParseFormat(s string) (Format, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return Text, nil
+	}
+	if s == "yml" {
+		return YAML, nil
+	}
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format %q (use text, json, yaml, csv, or tsv)", s)
+}
+
+// Table is the flattened form of a result used for CSV and TSV.
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// Render writes data in format f. JSON and YAML encode data itself, using
+// its json tags for field names; CSV and TSV write table.
+func Render(w io.Writer, f Format, data any, table Table) error {
+	switch f {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	case YAML:
+		return writeYAML(w, data)
+	case CSV, TSV:
+		cw := csv.NewWriter(w)
+		if f == TSV {
+			cw.Comma = '\t'
+		}
+		if err := cw.Write(table.Header); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(table.Rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	}
+	return fmt.Errorf("format %q cannot render structured data", f)
+}
+
+// writeYAML encodes data via its JSON form so YAML keys match the json tags
+// and keep struct field order.
+func writeYAML(w io.Writer, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	node, err := yamlNode(dec)
+	if err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// yamlNode converts the next JSON value from dec into a YAML node.
+func yamlNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		if v == '{' {
+			node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := yamlNode(dec)
+				if err != nil {
+					return nil, err
+				}
+				key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keyTok.(string)}
+				node.Content = append(node.Content, key, value)
+			}
+			_, err := dec.Token() // closing '}'
+			return node, err
+		}
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for dec.More() {
+			item, err := yamlNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, item)
+		}
+		_, err := dec.Token() // closing ']'
+		return node, err
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(v)}, nil
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", tok)
+}
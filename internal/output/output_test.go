@@ -0,0 +1,77 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+var sampleGames = []nba.Game{{
+	ID: "0022400061", GameStatus: 3, GameStatusText: "Final", Period: 4,
+	GameTimeUTC: "2024-10-22T23:30:00Z",
+	HomeTeam:    nba.Team{Tricode: "BOS", Score: 132},
+	AwayTeam:    nba.Team{Tricode: "NYK", Score: 109},
+}}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"": Text, "JSON": JSON, "yml": YAML, "tsv": TSV} {
+		got, err := ParseFormat(in)
+		if err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+}
+
+func TestRender_JSONUsesJSONTags(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, JSON, sampleGames, GamesTable(sampleGames)); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if decoded[0]["gameId"] != "0022400061" {
+		t.Fatalf("expected gameId key, got %v", decoded[0])
+	}
+}
+
+func TestRender_YAMLKeepsFieldOrder(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, YAML, sampleGames, GamesTable(sampleGames)); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "- gameId: \"0022400061\"") {
+		t.Fatalf("expected gameId first and quoted: %s", out)
+	}
+	if strings.Index(out, "gameStatus:") > strings.Index(out, "homeTeam:") {
+		t.Fatalf("expected struct field order to be preserved: %s", out)
+	}
+}
+
+func TestRender_CSVAndTSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, CSV, sampleGames, GamesTable(sampleGames)); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[0] != strings.Join(GameColumns, ",") {
+		t.Fatalf("unexpected CSV: %s", buf.String())
+	}
+	if lines[1] != "0022400061,3,Final,4,,2024-10-22T23:30:00Z,NYK,109,BOS,132,Final" {
+		t.Fatalf("unexpected CSV row: %s", lines[1])
+	}
+
+	buf.Reset()
+	Render(&buf, TSV, sampleGames, GamesTable(sampleGames))
+	if !strings.HasPrefix(buf.String(), "game_id\tstatus\t") {
+		t.Fatalf("expected tab-separated header: %s", buf.String())
+	}
+}
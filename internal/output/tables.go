@@ -0,0 +1,221 @@
+package output
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/internetdrew/bball/internal/cache"
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/standings"
+)
+
+// GameColumns is the CSV/TSV schema for games, used by games and schedule.
+var GameColumns = []string{
+	"game_id", "status", "status_text", "period", "game_clock", "start_time_utc",
+	"away_team", "away_score", "home_team", "home_score", "state",
+}
+
+func gameRow(g nba.Game) []string {
+	return []string{
+		g.ID,
+		strconv.Itoa(g.GameStatus),
+		g.GameStatusText,
+		strconv.Itoa(g.Period),
+		string(g.GameClock),
+		g.StartTimeUTC(),
+		g.AwayTeam.Tricode,
+		strconv.Itoa(g.AwayTeam.Score),
+		g.HomeTeam.Tricode,
+		strconv.Itoa(g.HomeTeam.Score),
+		g.State().String(),
+	}
+}
+
+// GamesTable flattens games to one row each.
+func GamesTable(games []nba.Game) Table {
+	t := Table{Header: GameColumns}
+	for _, g := range games {
+		t.Rows = append(t.Rows, gameRow(g))
+	}
+	return t
+}
+
+// SummaryTable flattens a game summary to a single row: the game columns
+// followed by last_updated, age_seconds and stale. Top performers are only
+// in JSON and YAML.
+func SummaryTable(s nba.GameSummary) Table {
+	return Table{
+		Header: append(append([]string{}, GameColumns...), "last_updated", "age_seconds", "stale"),
+		Rows:   [][]string{append(gameRow(s.Game), s.LastUpdated, strconv.Itoa(s.Age), strconv.FormatBool(s.Stale))},
+	}
+}
+
+// PreviewTable flattens a game preview to a single row: the game columns
+// followed by each team's rest days (empty for a first game) and the game ID
+// of the last meeting.
+func PreviewTable(p nba.Preview) Table {
+	lastMeeting := ""
+	if p.LastMeeting != nil {
+		lastMeeting = p.LastMeeting.Game.ID
+	}
+	return Table{
+		Header: append(append([]string{}, GameColumns...), "away_rest_days", "home_rest_days", "last_meeting"),
+		Rows:   [][]string{append(gameRow(p.Game), restDays(p.AwayRest), restDays(p.HomeRest), lastMeeting)},
+	}
+}
+
+func restDays(days *int) string {
+	if days == nil {
+		return ""
+	}
+	return strconv.Itoa(*days)
+}
+
+// OffDayTable flattens a team's last and next games to a row each, led by
+// a "game" column of "last" or "next".
+func OffDayTable(o nba.OffDay) Table {
+	t := Table{Header: append([]string{"game"}, GameColumns...)}
+	if o.Last != nil {
+		t.Rows = append(t.Rows, append([]string{"last"}, gameRow(*o.Last)...))
+	}
+	if o.Next != nil {
+		t.Rows = append(t.Rows, append([]string{"next"}, gameRow(*o.Next)...))
+	}
+	return t
+}
+
+// HeadToHeadTable flattens a season series to one row per meeting: the game
+// columns followed by the result from the first team's side. Totals and the
+// next meeting are only in JSON and YAML.
+func HeadToHeadTable(h nba.HeadToHead) Table {
+	t := Table{Header: append(append([]string{}, GameColumns...), "team", "home", "winner", "margin", "series")}
+	for _, m := range h.Meetings {
+		series := ""
+		if m.Series != nil {
+			series = m.Series.String()
+		}
+		t.Rows = append(t.Rows, append(gameRow(m.Game),
+			h.Team, strconv.FormatBool(m.Home), m.Winner, strconv.Itoa(m.Margin), series))
+	}
+	return t
+}
+
+// StandingsTable flattens standings to one row per team, in group order.
+func StandingsTable(groups []standings.Group) Table {
+	t := Table{Header: []string{
+		"group", "rank", "team", "wins", "losses", "pct", "games_back",
+		"home", "road", "conference", "division", "last_10", "streak", "points_for", "points_against",
+	}}
+	for _, g := range groups {
+		for _, team := range g.Teams {
+			t.Rows = append(t.Rows, []string{
+				g.Name, strconv.Itoa(team.Rank), team.Tricode, strconv.Itoa(team.Wins), strconv.Itoa(team.Losses),
+				strconv.FormatFloat(team.Pct, 'f', 3, 64), strconv.FormatFloat(team.GamesBack, 'f', 1, 64),
+				team.Home.String(), team.Road.String(), team.ConferenceRecord.String(), team.DivisionRecord.String(),
+				team.LastTen.String(), team.Streak, strconv.Itoa(team.PointsFor), strconv.Itoa(team.PointsAgainst),
+			})
+		}
+	}
+	return t
+}
+
+// PlayoffPictureTable flattens the playoff picture to one row per team,
+// seeded within its conference.
+func PlayoffPictureTable(pictures []standings.Picture) Table {
+	t := Table{Header: []string{
+		"conference", "seed", "section", "marker", "team", "wins", "losses", "pct", "games_back", "games_left",
+		"playoffs_magic", "playoffs_elimination", "play_in_magic", "play_in_elimination",
+	}}
+	for _, p := range pictures {
+		sections := []struct {
+			name    string
+			entries []standings.Entry
+		}{{"playoffs", p.Playoffs}, {"play_in", p.PlayIn}, {"out", p.Out}}
+		for _, section := range sections {
+			for _, e := range section.entries {
+				t.Rows = append(t.Rows, []string{
+					p.Conference, strconv.Itoa(e.Seed), section.name, e.Marker, e.Tricode,
+					strconv.Itoa(e.Wins), strconv.Itoa(e.Losses),
+					strconv.FormatFloat(e.Pct, 'f', 3, 64), strconv.FormatFloat(e.GamesBack, 'f', 1, 64), strconv.Itoa(e.GamesLeft),
+					strconv.Itoa(e.Playoffs.Magic), strconv.Itoa(e.Playoffs.Elimination),
+					strconv.Itoa(e.PlayIn.Magic), strconv.Itoa(e.PlayIn.Elimination),
+				})
+			}
+		}
+	}
+	return t
+}
+
+// BracketTable flattens playoff series to one row each, by round.
+func BracketTable(series []nba.Series) Table {
+	t := Table{Header: []string{
+		"round", "number", "conference", "top", "top_seed", "top_wins",
+		"bottom", "bottom_seed", "bottom_wins", "status", "winner",
+	}}
+	for _, s := range series {
+		t.Rows = append(t.Rows, []string{
+			strconv.Itoa(s.Round), strconv.Itoa(s.Number), s.Conference,
+			s.Top.Tricode, strconv.Itoa(s.Top.Seed), strconv.Itoa(s.Top.Wins),
+			s.Bottom.Tricode, strconv.Itoa(s.Bottom.Seed), strconv.Itoa(s.Bottom.Wins),
+			s.Status(), s.Winner,
+		})
+	}
+	return t
+}
+
+// BoxscoreTable flattens a box score to one row per player, away team first.
+func BoxscoreTable(box nba.Boxscore) Table {
+	t := Table{Header: []string{
+		"game_id", "team", "player", "position", "starter", "minutes",
+		"fgm", "fga", "fg3m", "fg3a", "ftm", "fta", "oreb", "dreb", "reb",
+		"ast", "stl", "blk", "tov", "pf", "plus_minus", "pts", "dnp_reason",
+	}}
+	for _, team := range []nba.BoxscoreTeam{box.Game.AwayTeam, box.Game.HomeTeam} {
+		for _, p := range team.Players {
+			s := p.Statistics
+			dnp := ""
+			if p.DidNotPlay() {
+				dnp = p.DNPReason()
+			}
+			t.Rows = append(t.Rows, []string{
+				box.Game.ID, team.Tricode, p.Name, p.Position, strconv.FormatBool(p.IsStarter()),
+				strconv.Itoa(s.MinutesPlayed()),
+				strconv.Itoa(s.FieldGoalsMade), strconv.Itoa(s.FieldGoalsAttempted),
+				strconv.Itoa(s.ThreePointersMade), strconv.Itoa(s.ThreePointersAttempted),
+				strconv.Itoa(s.FreeThrowsMade), strconv.Itoa(s.FreeThrowsAttempted),
+				strconv.Itoa(s.ReboundsOffensive), strconv.Itoa(s.ReboundsDefensive), strconv.Itoa(s.ReboundsTotal),
+				strconv.Itoa(s.Assists), strconv.Itoa(s.Steals), strconv.Itoa(s.Blocks),
+				strconv.Itoa(s.Turnovers), strconv.Itoa(s.FoulsPersonal),
+				strconv.Itoa(int(s.PlusMinusPoints)), strconv.Itoa(s.Points), dnp,
+			})
+		}
+	}
+	return t
+}
+
+// ActionsTable flattens play-by-play actions to one row each.
+func ActionsTable(actions []nba.Action) Table {
+	t := Table{Header: []string{
+		"action_number", "period", "clock", "team", "kind", "action_type", "sub_type",
+		"player", "score_away", "score_home", "description",
+	}}
+	for _, a := range actions {
+		t.Rows = append(t.Rows, []string{
+			strconv.Itoa(a.Number), strconv.Itoa(a.Period), a.ClockDisplay(), a.TeamTricode,
+			string(a.Kind()), a.ActionType, a.SubType, a.PlayerNameI,
+			a.ScoreAway, a.ScoreHome, a.Description,
+		})
+	}
+	return t
+}
+
+// CacheTable flattens cache entries to one row each.
+func CacheTable(entries []cache.Entry) Table {
+	t := Table{Header: []string{"url", "size", "fetched_at", "etag", "last_modified"}}
+	for _, e := range entries {
+		t.Rows = append(t.Rows, []string{
+			e.URL, strconv.FormatInt(e.Size, 10), e.FetchedAt.UTC().Format(time.RFC3339), e.ETag, e.LastModified,
+		})
+	}
+	return t
+}
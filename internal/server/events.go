@@ -0,0 +1,77 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/internetdrew/bball/internal/stream"
+)
+
+// keepaliveInterval is how often an idle event stream gets a comment line,
+// so proxies don't time it out.
+const keepaliveInterval = 15 * time.Second
+
+// retryMillis is how long EventSource clients wait before reconnecting.
+const retryMillis = 3000
+
+// handleEvents serves GET /events, the hub's events as Server-Sent Events.
+// A reconnecting client resumes after its Last-Event-ID header, or the
+// lastEventId query parameter for clients that can't set headers.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if s.Hub == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("live events are not enabled"))
+		return
+	}
+
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get("lastEventId")
+	}
+	initial, events, cancel := s.Hub.Subscribe(lastID)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	fmt.Fprintf(w, "retry: %d\n\n", retryMillis)
+	for _, e := range initial {
+		writeEvent(w, e)
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	keepalive := time.NewTicker(keepaliveInterval)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.stopping:
+			return
+		case e, ok := <-events:
+			if !ok {
+				// Too far behind; the client reconnects and resumes.
+				return
+			}
+			writeEvent(w, e)
+		case <-keepalive.C:
+			io.WriteString(w, ": keepalive\n\n")
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes e as one SSE message named after its type.
+func writeEvent(w io.Writer, e stream.Event) {
+	data, _ := json.Marshal(e)
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+}
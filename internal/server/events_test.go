@@ -0,0 +1,223 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/internetdrew/bball/internal/stream"
+)
+
+// newStreamServer starts a hub against the fake CDN and waits for its
+// first poll, returning the snapshot that poll published.
+func newStreamServer(t *testing.T, up *upstream) (*httptest.Server, stream.Event) {
+	t.Helper()
+	s := newTestServer(t, up)
+	s.Hub = stream.NewHub(s.Scoreboard)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	_, events, unsubscribe := s.Hub.Subscribe("")
+	defer unsubscribe()
+	go s.Hub.Run(ctx)
+
+	var first stream.Event
+	select {
+	case first = <-events:
+	case <-time.After(5 * time.Second):
+		t.Fatal("hub never polled")
+	}
+
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return ts, first
+}
+
+// readSSE reads messages from an event stream until it has n of them,
+// skipping comments and the retry hint.
+func readSSE(t *testing.T, r *bufio.Reader, n int) []map[string]string {
+	t.Helper()
+	var messages []map[string]string
+	msg := map[string]string{}
+	for len(messages) < n {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading stream: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			if msg["data"] != "" {
+				messages = append(messages, msg)
+			}
+			msg = map[string]string{}
+			continue
+		}
+		if field, value, ok := strings.Cut(line, ": "); ok && field != "" {
+			msg[field] = value
+		}
+	}
+	return messages
+}
+
+func openSSE(t *testing.T, url, lastID string) *bufio.Reader {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, url+"/events", nil)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", got)
+	}
+	return bufio.NewReader(resp.Body)
+}
+
+func TestEvents_SSE(t *testing.T) {
+	up := &upstream{}
+	ts, first := newStreamServer(t, up)
+
+	for i := 0; i < 3; i++ {
+		msg := readSSE(t, openSSE(t, ts.URL, ""), 1)[0]
+		if msg["event"] != "snapshot" || msg["id"] != fmt.Sprint(first.ID) {
+			t.Fatalf("client %d: first message %v", i, msg)
+		}
+		var e stream.Event
+		if err := json.Unmarshal([]byte(msg["data"]), &e); err != nil || len(e.Games) != 2 {
+			t.Fatalf("client %d: invalid snapshot %q: %v", i, msg["data"], err)
+		}
+	}
+	res, err := http.Get(ts.URL + "/games")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if n := up.scoreboard.Load(); n != 1 {
+		t.Fatalf("expected one upstream poll for three clients and /games, got %d", n)
+	}
+
+	// Resuming from just before the first poll replays it from the backlog.
+	msg := readSSE(t, openSSE(t, ts.URL, fmt.Sprint(first.ID-1)), 1)[0]
+	if msg["id"] != fmt.Sprint(first.ID) || msg["event"] != "snapshot" {
+		t.Fatalf("resumed message %v", msg)
+	}
+}
+
+func TestEvents_Disabled(t *testing.T) {
+	h := newTestServer(t, &upstream{}).Handler()
+	for _, path := range []string{"/events", "/ws"} {
+		if rec := get(t, h, path); rec.Code != http.StatusNotFound {
+			t.Errorf("%s without a hub: status %d", path, rec.Code)
+		}
+	}
+}
+
+// dialWebSocket performs the opening handshake by hand.
+func dialWebSocket(t *testing.T, url string) (net.Conn, *bufio.Reader) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(url, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	// The key and accept value are the example from RFC 6455 section 1.3.
+	fmt.Fprintf(conn, "GET /ws HTTP/1.1\r\nHost: test\r\nUpgrade: websocket\r\nConnection: keep-alive, Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake status %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("Sec-WebSocket-Accept = %q", got)
+	}
+	return conn, r
+}
+
+// readServerFrame reads one unmasked frame with a short payload.
+func readServerFrame(t *testing.T, r *bufio.Reader) (byte, []byte) {
+	t.Helper()
+	var head [2]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		t.Fatal(err)
+	}
+	if head[1]&0x80 != 0 {
+		t.Fatal("server frames must not be masked")
+	}
+	length := int(head[1] & 0x7f)
+	if length == 126 {
+		var ext [2]byte
+		io.ReadFull(r, ext[:])
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatal(err)
+	}
+	return head[0] & 0x0f, payload
+}
+
+func writeClientFrame(t *testing.T, conn net.Conn, opcode byte, payload []byte) {
+	t.Helper()
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := conn.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebSocket(t *testing.T) {
+	ts, first := newStreamServer(t, &upstream{})
+	conn, r := dialWebSocket(t, ts.URL)
+
+	opcode, payload := readServerFrame(t, r)
+	var e stream.Event
+	if opcode != opText || json.Unmarshal(payload, &e) != nil || e.Type != stream.Snapshot || e.ID != first.ID {
+		t.Fatalf("first message opcode %d: %s", opcode, payload)
+	}
+
+	writeClientFrame(t, conn, opPing, []byte("hi"))
+	if opcode, payload := readServerFrame(t, r); opcode != opPong || string(payload) != "hi" {
+		t.Fatalf("expected pong, got opcode %d %q", opcode, payload)
+	}
+
+	writeClientFrame(t, conn, opClose, binary.BigEndian.AppendUint16(nil, closeNormal))
+	if opcode, payload := readServerFrame(t, r); opcode != opClose || binary.BigEndian.Uint16(payload) != closeNormal {
+		t.Fatalf("expected close, got opcode %d %q", opcode, payload)
+	}
+}
+
+func TestWebSocket_RequiresUpgrade(t *testing.T) {
+	ts, _ := newStreamServer(t, &upstream{})
+	resp, err := http.Get(ts.URL + "/ws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUpgradeRequired {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// fetchTimeout bounds a shared fetch, retries included. It runs apart from
+// any one request, so a client that hangs up doesn't cut it short for the
+// others.
+const fetchTimeout = time.Minute
+
+// failureTTL is how long a failed fetch is remembered, so that while
+// nba.com is down requests fail fast instead of each retrying in turn.
+const failureTTL = 5 * time.Second
+
+// memo holds the latest copy of an upstream document for every request to
+// share. Callers that arrive while it is being refreshed wait for that one
+// fetch instead of starting their own.
+type memo[T any] struct {
+	ttl   time.Duration
+	fetch func(context.Context) (T, error)
+
+	mu       sync.Mutex
+	value    T
+	fetched  time.Time
+	err      error
+	failed   time.Time
+	inflight chan struct{} // closed when the running fetch finishes
+}
+
+// get returns the cached value while it is younger than ttl and fetches a
+// new one otherwise. It also returns when the value was fetched. A caller
+// whose ctx ends stops waiting, but the fetch carries on for the rest.
+func (m *memo[T]) get(ctx context.Context, now time.Time) (T, time.Time, error) {
+	m.mu.Lock()
+	for {
+		switch {
+		case !m.fetched.IsZero() && now.Sub(m.fetched) < m.ttl:
+			value, fetched := m.value, m.fetched
+			m.mu.Unlock()
+			return value, fetched, nil
+		case m.err != nil && now.Sub(m.failed) < failureTTL:
+			err := m.err
+			m.mu.Unlock()
+			var zero T
+			return zero, time.Time{}, err
+		}
+
+		if m.inflight == nil {
+			m.inflight = make(chan struct{})
+			go m.refresh(now)
+		}
+		done := m.inflight
+		m.mu.Unlock()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			var zero T
+			return zero, time.Time{}, ctx.Err()
+		}
+		m.mu.Lock()
+	}
+}
+
+// refresh fetches the document on a context of its own and stores the
+// result, or the error, as of now.
+func (m *memo[T]) refresh(now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	value, err := m.fetch(ctx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil {
+		m.err, m.failed = err, now
+	} else {
+		m.value, m.fetched, m.err = value, now, nil
+	}
+	close(m.inflight)
+	m.inflight = nil
+}
+
+// cacheControl returns a Cache-Control value that lets clients and proxies
+// reuse a response for as long as the data behind it stays fresh here.
+func cacheControl(fetched time.Time, ttl time.Duration, now time.Time) string {
+	remaining := ttl - now.Sub(fetched)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return fmt.Sprintf("public, max-age=%d", int(remaining.Seconds()))
+}
@@ -0,0 +1,377 @@
+// Package server exposes the nba package over a small read-only JSON API,
+// the backend of `bball serve`.
+package server
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/stream"
+	"github.com/internetdrew/bball/internal/util"
+)
+
+// ShutdownTimeout is how long in-flight requests get to finish once the
+// server is asked to stop.
+const ShutdownTimeout = 10 * time.Second
+
+//go:embed openapi.json
+var openAPI []byte
+
+// scheduleModes are the values /teams/{team}/schedule accepts for mode.
+var scheduleModes = map[string]bool{"upcoming": true, "recent": true, "season": true}
+
+// Server answers API requests from one shared, in-memory copy of the
+// scoreboard and league schedule, refreshed by Client at most once per
+// ScoreboardTTL and ScheduleTTL however many clients are asking.
+type Server struct {
+	Client *nba.Client
+	// Log receives one line per request; nil disables it.
+	Log *log.Logger
+	// Hub feeds /events and /ws; nil disables them. The caller runs it,
+	// normally polling with Scoreboard.
+	Hub *stream.Hub
+
+	scoreboard memo[*nba.Scoreboard]
+	schedule   memo[*nba.LeagueScheduleResponse]
+	now        func() time.Time
+
+	// stopping is closed when Serve starts shutting down, to end the event
+	// streams that would otherwise hold it open.
+	stopping chan struct{}
+	stopOnce sync.Once
+}
+
+// New returns a Server that fetches through client. The shared copies skip
+// client's disk cache: it keeps responses for the same TTLs, so a copy
+// could be nearly twice as old as Cache-Control says. An Offline client
+// keeps its cache, since that's all it has.
+func New(client *nba.Client) *Server {
+	fetcher := *client
+	if !fetcher.Offline {
+		fetcher.Cache = nil
+	}
+	return &Server{
+		Client:     client,
+		scoreboard: memo[*nba.Scoreboard]{ttl: client.ScoreboardTTL, fetch: fetcher.FetchScoreboard},
+		schedule:   memo[*nba.LeagueScheduleResponse]{ttl: client.ScheduleTTL, fetch: fetcher.FetchLeagueSchedule},
+		now:        time.Now,
+		stopping:   make(chan struct{}),
+	}
+}
+
+// Scoreboard returns the shared copy of today's scoreboard, fetching it
+// when it is older than ScoreboardTTL. A Hub polling with it shares the
+// fetch with the API's requests instead of polling nba.com on its own.
+func (s *Server) Scoreboard(ctx context.Context) (*nba.Scoreboard, error) {
+	board, _, err := s.scoreboard.get(ctx, s.now())
+	return board, err
+}
+
+// Handler returns the API's routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/openapi.json", s.handleOpenAPI)
+	mux.HandleFunc("/games", s.handleGames)
+	mux.HandleFunc("/games/", s.handleGame)
+	mux.HandleFunc("/teams/", s.handleTeam)
+	mux.HandleFunc("/events", s.handleEvents)
+	mux.HandleFunc("/ws", s.handleWebSocket)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no route for %s; see /openapi.json", r.URL.Path))
+	})
+	return s.logRequests(readOnly(mux))
+}
+
+// Serve answers requests on ln until ctx is cancelled, then shuts down
+// gracefully, giving in-flight requests up to ShutdownTimeout to finish.
+// Event streams end as soon as the shutdown starts.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	srv.RegisterOnShutdown(s.stop)
+
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdown, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdown)
+}
+
+// stop ends the event streams. It is safe to call more than once.
+func (s *Server) stop() {
+	s.stopOnce.Do(func() { close(s.stopping) })
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Write(openAPI)
+}
+
+// handleGames serves GET /games?date=, today's scoreboard by default or
+// any day's slate from the league schedule. Today comes from the schedule
+// too while the scoreboard still shows yesterday's games.
+func (s *Server) handleGames(w http.ResponseWriter, r *http.Request) {
+	now := s.now()
+	day, isToday, err := util.ParseDay(r.URL.Query().Get("date"), now.In(util.Location))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid date %q: use YYYY-MM-DD, today, yesterday, or tomorrow", r.URL.Query().Get("date")))
+		return
+	}
+
+	var games []nba.Game
+	if isToday {
+		board, fetched, err := s.scoreboard.get(r.Context(), now)
+		if err != nil {
+			writeUpstreamError(w, err)
+			return
+		}
+		if board.IsFor(day) {
+			games = board.Scoreboard.Games
+			w.Header().Set("Cache-Control", cacheControl(fetched, s.scoreboard.ttl, now))
+		} else {
+			// The scoreboard hasn't rolled over to today yet.
+			isToday = false
+		}
+	}
+	if !isToday {
+		schedule, fetched, err := s.schedule.get(r.Context(), now)
+		if err != nil {
+			writeUpstreamError(w, err)
+			return
+		}
+		games = schedule.GamesOn(day)
+		w.Header().Set("Cache-Control", cacheControl(fetched, s.schedule.ttl, now))
+	}
+
+	if games == nil {
+		games = []nba.Game{}
+	}
+	writeJSON(w, http.StatusOK, games)
+}
+
+// handleGame serves GET /games/{id}, looking in today's scoreboard first
+// for live data and then in the league schedule.
+func (s *Server) handleGame(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/games/")
+	if !nba.IsGameID(id) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%q is not a game ID", id))
+		return
+	}
+
+	now := s.now()
+	board, fetched, err := s.scoreboard.get(r.Context(), now)
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	for _, g := range board.Scoreboard.Games {
+		if g.ID == id {
+			w.Header().Set("Cache-Control", cacheControl(fetched, s.scoreboard.ttl, now))
+			writeJSON(w, http.StatusOK, g)
+			return
+		}
+	}
+
+	schedule, fetched, err := s.schedule.get(r.Context(), now)
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	game, ok := schedule.Game(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("game %s not found", id))
+		return
+	}
+	w.Header().Set("Cache-Control", cacheControl(fetched, s.schedule.ttl, now))
+	writeJSON(w, http.StatusOK, game)
+}
+
+// handleTeam serves GET /teams/{team}/schedule and /teams/{team}/live.
+func (s *Server) handleTeam(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/teams/"), "/")
+	if len(parts) != 2 || (parts[1] != "schedule" && parts[1] != "live") {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no route for %s; see /openapi.json", r.URL.Path))
+		return
+	}
+
+	franchise, err := nba.Resolve(parts[0])
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	if parts[1] == "live" {
+		s.handleTeamLive(w, r, franchise)
+	} else {
+		s.handleTeamSchedule(w, r, franchise)
+	}
+}
+
+func (s *Server) handleTeamSchedule(w http.ResponseWriter, r *http.Request, franchise *nba.Franchise) {
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = "upcoming"
+	}
+	if !scheduleModes[mode] {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid mode %q: use upcoming, recent, or season", mode))
+		return
+	}
+
+	now := s.now()
+	schedule, fetched, err := s.schedule.get(r.Context(), now)
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+
+	games, err := schedule.TeamSchedule(franchise, mode, s.Client.ScheduleWindow, now)
+	if err != nil && !errors.Is(err, nba.ErrTeamNotFound) {
+		writeUpstreamError(w, err)
+		return
+	}
+	if games == nil {
+		games = []nba.Game{}
+	}
+	w.Header().Set("Cache-Control", cacheControl(fetched, s.schedule.ttl, now))
+	writeJSON(w, http.StatusOK, games)
+}
+
+func (s *Server) handleTeamLive(w http.ResponseWriter, r *http.Request, franchise *nba.Franchise) {
+	now := s.now()
+	board, fetched, err := s.scoreboard.get(r.Context(), now)
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", cacheControl(fetched, s.scoreboard.ttl, now))
+	for _, g := range board.Scoreboard.Games {
+		if g.State().IsLive() && (franchise.Matches(g.HomeTeam) || franchise.Matches(g.AwayTeam)) {
+			updated, ok := board.UpdatedAt()
+			if !ok {
+				updated = fetched
+			}
+			summary := nba.NewGameSummary(g)
+			summary.SetUpdated(updated, now)
+			writeJSON(w, http.StatusOK, summary)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Errorf("no live game for the %s", franchise.FullName()))
+}
+
+// readOnly rejects anything but GET and HEAD.
+func readOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// statusRecorder remembers the status code a handler wrote.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer to flush
+// event streams and hijack WebSocket connections.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func (s *Server) logRequests(next http.Handler) http.Handler {
+	if s.Log == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		s.Log.Printf("%s %s %d %s", r.Method, r.URL.RequestURI(), rec.status, time.Since(start).Round(time.Millisecond))
+	})
+}
+
+// apiError is the body of every error response.
+type apiError struct {
+	Error      string   `json:"error"`
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, status, apiError{Error: err.Error()})
+}
+
+// writeTeamError reports a team name that didn't resolve: unknown names are
+// not found, ambiguous ones are a bad request listing the candidates.
+func writeTeamError(w http.ResponseWriter, err error) {
+	var ambiguous *nba.AmbiguousTeamError
+	if errors.As(err, &ambiguous) {
+		body := apiError{Error: err.Error()}
+		for _, c := range ambiguous.Candidates {
+			body.Candidates = append(body.Candidates, c.Tricode)
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		writeJSON(w, http.StatusBadRequest, body)
+		return
+	}
+	writeError(w, http.StatusNotFound, err)
+}
+
+// writeUpstreamError reports a failure to get data from nba.com.
+func writeUpstreamError(w http.ResponseWriter, err error) {
+	var httpErr *nba.HTTPError
+	switch {
+	case errors.Is(err, nba.ErrRateLimited):
+		if errors.As(err, &httpErr) && httpErr.RetryAfter > 0 {
+			w.Header().Set("Retry-After", fmt.Sprint(int(httpErr.RetryAfter.Seconds())))
+		}
+		writeError(w, http.StatusServiceUnavailable, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, err)
+	default:
+		writeError(w, http.StatusBadGateway, err)
+	}
+}
@@ -0,0 +1,355 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/internetdrew/bball/internal/cache"
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/stream"
+	"github.com/internetdrew/bball/internal/util"
+)
+
+const testScoreboard = `{"scoreboard":{"gameDate":"2024-11-01","games":[
+	{"gameId":"0022400100","gameStatus":2,"period":3,"homeTeam":{"teamTricode":"BOS","score":70},"awayTeam":{"teamTricode":"NYK","score":66},
+	 "gameLeaders":{"homeLeaders":{"name":"Jayson Tatum","teamTricode":"BOS","points":24}}},
+	{"gameId":"0022400101","gameStatus":1,"homeTeam":{"teamTricode":"LAL"},"awayTeam":{"teamTricode":"GSW"}}]},
+	"meta":{"time":"2024-11-01 20:15:00.000"}}`
+
+const testSchedule = `{"leagueSchedule":{"gameDates":[
+	{"gameDate":"10/22/2024 00:00:00","games":[
+		{"gameId":"0022400061","gameStatus":3,"gameDateTimeUTC":"2024-10-22T23:30:00Z","homeTeam":{"teamTricode":"BOS","score":132},"awayTeam":{"teamTricode":"NYK","score":109}}]},
+	{"gameDate":"11/01/2024 00:00:00","games":[
+		{"gameId":"0022400100","gameStatus":1,"gameDateTimeUTC":"2024-11-01T23:30:00Z","homeTeam":{"teamTricode":"BOS"},"awayTeam":{"teamTricode":"NYK"}}]}]}}`
+
+// upstream fakes the NBA CDN and counts requests per feed.
+type upstream struct {
+	scoreboard, schedule atomic.Int32
+	status               int           // when set, every request fails with it
+	scheduleDelay        time.Duration // how long schedule requests take
+}
+
+func newTestServer(t *testing.T, up *upstream) *Server {
+	t.Helper()
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if up.status != 0 {
+			w.WriteHeader(up.status)
+			return
+		}
+		if strings.Contains(r.URL.Path, "scoreboard") {
+			up.scoreboard.Add(1)
+			w.Write([]byte(testScoreboard))
+			return
+		}
+		up.schedule.Add(1)
+		time.Sleep(up.scheduleDelay)
+		w.Write([]byte(testSchedule))
+	}))
+	t.Cleanup(cdn.Close)
+
+	client := nba.NewClient()
+	client.ScoreboardURL = cdn.URL + "/scoreboard"
+	client.LeagueScheduleURL = cdn.URL + "/schedule"
+	client.MaxRetries = 0
+
+	s := New(client)
+	s.now = func() time.Time { return time.Date(2024, 11, 1, 20, 0, 0, 0, time.UTC) }
+	return s
+}
+
+func get(t *testing.T, h http.Handler, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("invalid JSON %q: %v", rec.Body.String(), err)
+	}
+	return v
+}
+
+func TestGames_TodaySharesOneFetch(t *testing.T) {
+	up := &upstream{}
+	h := newTestServer(t, up).Handler()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			get(t, h, "/games")
+		}()
+	}
+	wg.Wait()
+
+	rec := get(t, h, "/games")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+	if games := decode[[]nba.Game](t, rec); len(games) != 2 {
+		t.Fatalf("expected 2 games, got %d", len(games))
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "public, max-age=10" {
+		t.Fatalf("Cache-Control = %q", got)
+	}
+	if n := up.scoreboard.Load(); n != 1 {
+		t.Fatalf("expected one upstream fetch for 11 requests, got %d", n)
+	}
+}
+
+func TestGames_OtherDayFromSchedule(t *testing.T) {
+	old := util.Location
+	util.Location = time.UTC
+	defer func() { util.Location = old }()
+
+	h := newTestServer(t, &upstream{}).Handler()
+
+	rec := get(t, h, "/games?date=2024-10-22")
+	games := decode[[]nba.Game](t, rec)
+	if len(games) != 1 || games[0].ID != "0022400061" {
+		t.Fatalf("unexpected games %+v", games)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "public, max-age=21600" {
+		t.Fatalf("Cache-Control = %q", got)
+	}
+
+	if rec := get(t, h, "/games?date=2024-07-04"); rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != "[]" {
+		t.Fatalf("an empty day should be [], got %d %s", rec.Code, rec.Body)
+	}
+	if rec := get(t, h, "/games?date=11/01"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad date, got %d", rec.Code)
+	}
+}
+
+func TestGames_TodayBeforeScoreboardRollsOver(t *testing.T) {
+	old := util.Location
+	util.Location = time.UTC
+	defer func() { util.Location = old }()
+
+	up := &upstream{}
+	s := newTestServer(t, up)
+	// Just after midnight the scoreboard still has November 1st's games.
+	s.now = func() time.Time { return time.Date(2024, 11, 2, 0, 30, 0, 0, time.UTC) }
+
+	for _, target := range []string{"/games", "/games?date=2024-11-02"} {
+		rec := get(t, s.Handler(), target)
+		if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != "[]" {
+			t.Fatalf("%s: expected November 2nd's (empty) slate, got %d %s", target, rec.Code, rec.Body)
+		}
+	}
+	if up.schedule.Load() != 1 {
+		t.Fatalf("expected the slate from the schedule, got %d schedule fetches", up.schedule.Load())
+	}
+}
+
+func TestGame(t *testing.T) {
+	h := newTestServer(t, &upstream{}).Handler()
+
+	if g := decode[nba.Game](t, get(t, h, "/games/0022400100")); g.GameStatus != 2 {
+		t.Fatalf("today's game should come from the live scoreboard, got %+v", g)
+	}
+	if g := decode[nba.Game](t, get(t, h, "/games/0022400061")); g.HomeTeam.Score != 132 {
+		t.Fatalf("older game should come from the schedule, got %+v", g)
+	}
+	for _, target := range []string{"/games/0022499999", "/games/abc"} {
+		if rec := get(t, h, target); rec.Code != http.StatusNotFound {
+			t.Fatalf("%s: expected 404, got %d", target, rec.Code)
+		}
+	}
+}
+
+func TestTeamSchedule(t *testing.T) {
+	h := newTestServer(t, &upstream{}).Handler()
+
+	games := decode[[]nba.Game](t, get(t, h, "/teams/celtics/schedule?mode=season"))
+	if len(games) != 2 {
+		t.Fatalf("expected the whole season, got %d games", len(games))
+	}
+	if games := decode[[]nba.Game](t, get(t, h, "/teams/nyk/schedule?mode=recent")); len(games) != 1 {
+		t.Fatalf("expected one recent game, got %d", len(games))
+	}
+	if rec := get(t, h, "/teams/bos/schedule?mode=later"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad mode, got %d", rec.Code)
+	}
+
+	rec := get(t, h, "/teams/la/schedule")
+	body := decode[apiError](t, rec)
+	if rec.Code != http.StatusBadRequest || len(body.Candidates) != 2 {
+		t.Fatalf("ambiguous team: %d %+v", rec.Code, body)
+	}
+	if rec := get(t, h, "/teams/sonics/schedule"); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown team, got %d", rec.Code)
+	}
+}
+
+func TestTeamLive(t *testing.T) {
+	h := newTestServer(t, &upstream{}).Handler()
+
+	rec := get(t, h, "/teams/knicks/live")
+	summary := decode[nba.GameSummary](t, rec)
+	if rec.Code != http.StatusOK || summary.Game.ID != "0022400100" || summary.TopPerformers[0].PlayerName != "Jayson Tatum" {
+		t.Fatalf("unexpected summary %d %+v", rec.Code, summary)
+	}
+	if summary.LastUpdated != "2024-11-01T20:15:00Z" || summary.Stale {
+		t.Fatalf("expected the feed's own time, got %q (stale %v)", summary.LastUpdated, summary.Stale)
+	}
+	if rec := get(t, h, "/teams/lakers/live"); rec.Code != http.StatusNotFound {
+		t.Fatalf("a scheduled game isn't live, got %d", rec.Code)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	h := newTestServer(t, &upstream{}).Handler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/games", nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "GET, HEAD" {
+		t.Fatalf("POST: %d %v", rec.Code, rec.Header())
+	}
+	if rec := get(t, h, "/nope"); rec.Code != http.StatusNotFound || rec.Header().Get("Cache-Control") != "no-store" {
+		t.Fatalf("unknown route: %d %v", rec.Code, rec.Header())
+	}
+	if rec := get(t, h, "/health"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"ok"`) {
+		t.Fatalf("health: %d %s", rec.Code, rec.Body)
+	}
+	if rec := get(t, h, "/openapi.json"); rec.Code != http.StatusOK || !json.Valid(rec.Body.Bytes()) {
+		t.Fatalf("openapi.json: %d", rec.Code)
+	}
+
+	for status, want := range map[int]int{http.StatusServiceUnavailable: http.StatusBadGateway, http.StatusTooManyRequests: http.StatusServiceUnavailable} {
+		h := newTestServer(t, &upstream{status: status}).Handler()
+		if rec := get(t, h, "/games"); rec.Code != want {
+			t.Errorf("upstream %d: got %d, want %d", status, rec.Code, want)
+		}
+	}
+}
+
+func TestServe_ShutsDownGracefully(t *testing.T) {
+	up := &upstream{scheduleDelay: 300 * time.Millisecond}
+	s := newTestServer(t, up)
+	s.Hub = stream.NewHub(s.Scoreboard)
+	hubCtx, stopHub := context.WithCancel(context.Background())
+	defer stopHub()
+	go s.Hub.Run(hubCtx)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("can't listen:", err)
+	}
+	base := "http://" + ln.Addr().String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx, ln) }()
+
+	res, err := http.Get(base + "/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	// An open event stream, and a request still waiting on nba.com.
+	events := openSSE(t, base, "")
+	slow := make(chan *http.Response, 1)
+	go func() {
+		res, err := http.Get(base + "/games?date=2024-10-22")
+		if err != nil {
+			t.Error(err)
+		}
+		slow <- res
+	}()
+	for up.schedule.Load() == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cancel()
+	if res := <-slow; res == nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("the in-flight request should finish, got %+v", res)
+	} else {
+		res.Body.Close()
+	}
+	if _, err := io.ReadAll(events); err != nil {
+		t.Fatalf("the event stream should end cleanly: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Serve returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after cancel")
+	}
+}
+
+func TestNew_SkipsDiskCache(t *testing.T) {
+	up := &upstream{}
+	s := newTestServer(t, up)
+	s.Client.Cache = cache.New(t.TempDir())
+
+	// Two servers over the same client: the second must not be handed the
+	// first one's response from disk.
+	for i := 0; i < 2; i++ {
+		if rec := get(t, New(s.Client).Handler(), "/games"); rec.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", rec.Code, rec.Body)
+		}
+	}
+	if n := up.scoreboard.Load(); n != 2 {
+		t.Fatalf("expected each server to fetch, got %d fetches", n)
+	}
+}
+
+func TestMemo_RemembersFailure(t *testing.T) {
+	var calls atomic.Int32
+	m := memo[int]{ttl: time.Minute, fetch: func(context.Context) (int, error) {
+		calls.Add(1)
+		return 0, errors.New("nba.com is down")
+	}}
+
+	now := time.Date(2024, 11, 1, 20, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if _, _, err := m.get(context.Background(), now); err == nil {
+			t.Fatal("expected the fetch error")
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected one fetch while the failure is remembered, got %d", n)
+	}
+	if _, _, err := m.get(context.Background(), now.Add(failureTTL)); err == nil || calls.Load() != 2 {
+		t.Fatalf("expected a new fetch once the failure is forgotten, got %v after %d", err, calls.Load())
+	}
+}
+
+func TestMemo_CallerHangingUpDoesNotCancelFetch(t *testing.T) {
+	release := make(chan struct{})
+	m := memo[int]{ttl: time.Minute, fetch: func(ctx context.Context) (int, error) {
+		<-release
+		return 42, ctx.Err()
+	}}
+	now := time.Date(2024, 11, 1, 20, 0, 0, 0, time.UTC)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := m.get(ctx, now); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the caller's own cancellation, got %v", err)
+	}
+
+	close(release)
+	if v, _, err := m.get(context.Background(), now); err != nil || v != 42 {
+		t.Fatalf("the shared fetch should finish for others: %d, %v", v, err)
+	}
+}
@@ -0,0 +1,280 @@
+package server
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// websocketGUID is the key suffix RFC 6455 section 1.3 hashes into
+// Sec-WebSocket-Accept.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	// pingInterval is how often an open WebSocket is pinged, which keeps
+	// proxies from closing it and notices clients that went away.
+	pingInterval = 30 * time.Second
+	// writeTimeout bounds each frame write so a stuck client can't hold a
+	// connection open forever.
+	writeTimeout = 10 * time.Second
+	// maxClientFrame is the largest frame accepted from a client. Clients
+	// have nothing to say beyond control frames.
+	maxClientFrame = 4096
+)
+
+// WebSocket opcodes.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// Close status codes.
+const (
+	closeNormal        = 1000
+	closeGoingAway     = 1001
+	closeProtocolError = 1002
+	closeTooBig        = 1009
+)
+
+// handleWebSocket serves GET /ws, the same events as /events with each one
+// sent as a JSON text message. Browsers can't set headers on a WebSocket,
+// so resuming is done with the lastEventId query parameter.
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	if s.Hub == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("live events are not enabled"))
+		return
+	}
+	if !headerHasToken(r.Header, "Connection", "upgrade") || !headerHasToken(r.Header, "Upgrade", "websocket") {
+		w.Header().Set("Upgrade", "websocket")
+		writeError(w, http.StatusUpgradeRequired, fmt.Errorf("/ws needs a WebSocket upgrade; use /events for Server-Sent Events"))
+		return
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		writeError(w, http.StatusUpgradeRequired, fmt.Errorf("unsupported WebSocket version %q", r.Header.Get("Sec-WebSocket-Version")))
+		return
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("missing Sec-WebSocket-Key"))
+		return
+	}
+
+	netConn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("WebSocket upgrade failed: %w", err))
+		return
+	}
+	defer netConn.Close()
+
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", acceptKey(key))
+	if err := rw.Flush(); err != nil {
+		return
+	}
+
+	initial, events, cancel := s.Hub.Subscribe(r.URL.Query().Get("lastEventId"))
+	defer cancel()
+
+	conn := &wsConn{conn: netConn}
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		conn.readLoop(rw.Reader)
+	}()
+
+	for _, e := range initial {
+		if conn.writeJSON(e) != nil {
+			return
+		}
+	}
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-s.stopping:
+			conn.close(closeGoingAway, "server shutting down")
+			return
+		case e, ok := <-events:
+			if !ok {
+				conn.close(closeGoingAway, "too far behind; reconnect with lastEventId")
+				return
+			}
+			if conn.writeJSON(e) != nil {
+				return
+			}
+		case <-ping.C:
+			if conn.write(opPing, nil) != nil {
+				return
+			}
+		}
+	}
+}
+
+// acceptKey returns the Sec-WebSocket-Accept value for a client's key.
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// headerHasToken reports whether the comma-separated header name contains
+// token, ignoring case.
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wsConn is the server side of a WebSocket. Writes come from both the
+// event loop and the read loop's pongs, so they are serialized.
+type wsConn struct {
+	conn net.Conn
+
+	mu     sync.Mutex
+	closed bool // a close frame has been sent
+}
+
+// write sends one unfragmented, unmasked frame.
+func (c *wsConn) write(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return net.ErrClosed
+	}
+	if opcode == opClose {
+		c.closed = true
+	}
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xffff:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	_, err := c.conn.Write(append(header, payload...))
+	return err
+}
+
+func (c *wsConn) writeJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.write(opText, data)
+}
+
+// close sends a close frame with a status code and reason.
+func (c *wsConn) close(code int, reason string) {
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	c.write(opClose, append(payload, reason...))
+}
+
+// readLoop answers pings and close frames until the client goes away or
+// breaks the protocol. Anything else a client sends is ignored.
+func (c *wsConn) readLoop(r *bufio.Reader) {
+	for {
+		opcode, payload, err := readFrame(r)
+		switch {
+		case errors.Is(err, errFrameTooBig):
+			c.close(closeTooBig, err.Error())
+			return
+		case errors.Is(err, errProtocol):
+			c.close(closeProtocolError, err.Error())
+			return
+		case err != nil:
+			return
+		}
+
+		switch opcode {
+		case opPing:
+			c.write(opPong, payload)
+		case opClose:
+			c.close(closeNormal, "")
+			return
+		}
+	}
+}
+
+var (
+	errProtocol    = errors.New("protocol error")
+	errFrameTooBig = errors.New("frame too big")
+)
+
+// readFrame reads one frame from a client and unmasks its payload.
+func readFrame(r *bufio.Reader) (opcode byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return 0, nil, err
+	}
+	opcode = head[0] & 0x0f
+	masked := head[1]&0x80 != 0
+	length := uint64(head[1] & 0x7f)
+
+	switch opcode {
+	case opContinuation, opText, opBinary, opPing, opPong, opClose:
+	default:
+		return 0, nil, fmt.Errorf("%w: unknown opcode %#x", errProtocol, opcode)
+	}
+	if !masked {
+		return 0, nil, fmt.Errorf("%w: client frames must be masked", errProtocol)
+	}
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > maxClientFrame {
+		return 0, nil, fmt.Errorf("%w: %d bytes", errFrameTooBig, length)
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(r, mask[:]); err != nil {
+		return 0, nil, err
+	}
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return opcode, payload, nil
+}
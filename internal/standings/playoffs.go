@@ -0,0 +1,159 @@
+package standings
+
+import (
+	"sort"
+)
+
+// PlayoffSeeds is how many teams per conference go straight to the
+// playoffs; seeds PlayoffSeeds+1 through PlayoffField meet in the play-in
+// tournament.
+const PlayoffSeeds = 6
+
+// Markers, as printed next to a team in the playoff picture. A team gets
+// the first one that applies.
+const (
+	ClinchedConference = "z" // clinched the conference's No. 1 seed
+	ClinchedDivision   = "y" // clinched its division
+	ClinchedPlayoffs   = "x" // clinched a top-six seed
+	Eliminated         = "e" // can't finish in the top ten
+	PlayInAtBest       = "o" // can't finish in the top six
+)
+
+// Race is a team's chances against one cut line, like the top six of its
+// conference. Both numbers count wins and losses the same way the classic
+// magic number does, and ignore tiebreakers, so a team is only called
+// clinched or eliminated once no tiebreak could change it.
+type Race struct {
+	// Magic is how many of the team's wins, or losses by the closest team
+	// that could still pass it, clinch a place above the line. 0 means
+	// clinched.
+	Magic int `json:"magic_number"`
+	// Elimination is how many of the team's losses, or wins by the team it
+	// has to catch, put it out of reach of the line. 0 means eliminated.
+	Elimination int `json:"elimination_number"`
+}
+
+// Clinched reports whether the team is sure to finish above the line.
+func (r Race) Clinched() bool { return r.Magic == 0 }
+
+// Eliminated reports whether the team can no longer reach the line.
+func (r Race) Eliminated() bool { return r.Elimination == 0 }
+
+// Entry is one team's place in the playoff picture.
+type Entry struct {
+	Seed      int     `json:"seed"`
+	Tricode   string  `json:"team"`
+	Name      string  `json:"name"`
+	Wins      int     `json:"wins"`
+	Losses    int     `json:"losses"`
+	Pct       float64 `json:"pct"`
+	GamesBack float64 `json:"games_back"`
+	GamesLeft int     `json:"games_left"`
+	Marker    string  `json:"marker,omitempty"`
+	// Playoffs is the race for the top six, PlayIn the race for the top ten.
+	Playoffs Race `json:"playoffs"`
+	PlayIn   Race `json:"play_in"`
+}
+
+// Picture is one conference split the way the postseason would be if the
+// season ended today.
+type Picture struct {
+	Conference string     `json:"conference"`
+	Playoffs   []Entry    `json:"playoffs"`
+	PlayIn     []Entry    `json:"play_in"`
+	Out        []Entry    `json:"out"`
+	Tiebreaks  []Tiebreak `json:"tiebreaks,omitempty"`
+}
+
+// PlayoffPicture seeds each conference from results, East first, and works
+// out every team's markers and magic and elimination numbers from the games
+// in remaining.
+func PlayoffPicture(results, remaining []Result) []Picture {
+	left := map[string]int{}
+	for _, r := range remaining {
+		left[r.Home]++
+		left[r.Away]++
+	}
+
+	var pictures []Picture
+	for _, g := range Standings(results, Conference) {
+		p := Picture{Conference: g.Name, Tiebreaks: g.Tiebreaks}
+		for _, t := range g.Teams {
+			e := Entry{
+				Seed: t.Rank, Tricode: t.Tricode, Name: t.Name,
+				Wins: t.Wins, Losses: t.Losses, Pct: t.Pct, GamesBack: t.GamesBack,
+				GamesLeft: left[t.Tricode],
+				Playoffs:  race(t, g.Teams, left, PlayoffSeeds),
+				PlayIn:    race(t, g.Teams, left, PlayoffField),
+			}
+			e.Marker = marker(t, g.Teams, left, e)
+
+			switch {
+			case e.Seed <= PlayoffSeeds:
+				p.Playoffs = append(p.Playoffs, e)
+			case e.Seed <= PlayoffField:
+				p.PlayIn = append(p.PlayIn, e)
+			default:
+				p.Out = append(p.Out, e)
+			}
+		}
+		pictures = append(pictures, p)
+	}
+	return pictures
+}
+
+// marker returns the first marker that applies to t.
+func marker(t Team, conference []Team, left map[string]int, e Entry) string {
+	var division []Team
+	for _, o := range conference {
+		if o.Division == t.Division {
+			division = append(division, o)
+		}
+	}
+
+	switch {
+	case race(t, conference, left, 1).Clinched():
+		return ClinchedConference
+	case race(t, division, left, 1).Clinched():
+		return ClinchedDivision
+	case e.Playoffs.Clinched():
+		return ClinchedPlayoffs
+	case e.PlayIn.Eliminated():
+		return Eliminated
+	case e.Playoffs.Eliminated():
+		return PlayInAtBest
+	}
+	return ""
+}
+
+// race returns t's magic and elimination numbers for finishing in the top
+// places of group, which includes t.
+func race(t Team, group []Team, left map[string]int, places int) Race {
+	var most, now []int
+	for _, o := range group {
+		if o.Tricode == t.Tricode {
+			continue
+		}
+		most = append(most, o.Wins+left[o.Tricode])
+		now = append(now, o.Wins)
+	}
+	if places > len(most) {
+		return Race{Magic: 0, Elimination: t.Wins + left[t.Tricode] + 1}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(most)))
+	sort.Sort(sort.Reverse(sort.IntSlice(now)))
+
+	// t is in once fewer than places teams can still reach its wins, and out
+	// once places teams already have more than it can reach.
+	return Race{
+		Magic:       max(0, most[places-1]-t.Wins+1),
+		Elimination: max(0, t.Wins+left[t.Tricode]-now[places-1]+1),
+	}
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,70 @@
+package standings
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRace(t *testing.T) {
+	group := []Team{
+		{Tricode: "A", Wins: 10},
+		{Tricode: "B", Wins: 9},
+		{Tricode: "C", Wins: 8},
+		{Tricode: "D", Wins: 3},
+	}
+	left := map[string]int{"B": 2, "C": 1, "D": 1}
+
+	tests := []struct {
+		team   int
+		places int
+		want   Race
+	}{
+		{0, 1, Race{Magic: 2, Elimination: 2}}, // B can still reach 11
+		{0, 2, Race{Magic: 0, Elimination: 3}}, // only B can reach 10
+		{2, 2, Race{Magic: 3, Elimination: 1}}, // C can at best tie B's 9 now
+		{3, 2, Race{Magic: 8, Elimination: 0}}, // D's 4 can't catch two teams
+		{3, 4, Race{Magic: 0, Elimination: 5}}, // fewer rivals than places
+	}
+	for _, tt := range tests {
+		if got := race(group[tt.team], group, left, tt.places); got != tt.want {
+			t.Errorf("race(%s, top %d) = %+v, want %+v", group[tt.team].Tricode, tt.places, got, tt.want)
+		}
+	}
+}
+
+func TestPlayoffPicture(t *testing.T) {
+	// BOS beats the rest of the East and NYK beats everyone but BOS; one
+	// game is left, between WAS and CHA.
+	var results []Result
+	n := 0
+	for _, team := range []string{"NYK", "ATL", "BKN", "CHA", "CHI", "CLE", "DET", "IND", "MIA", "MIL", "ORL", "PHI", "TOR", "WAS"} {
+		n++
+		results = append(results, final(n, "BOS", team, 5))
+		if team != "NYK" {
+			n++
+			results = append(results, final(n, "NYK", team, 5))
+		}
+	}
+	remaining := []Result{{GameID: "0022401230", Home: "WAS", Away: "CHA"}}
+
+	east := PlayoffPicture(results, remaining)[0]
+	if east.Conference != "East" || len(east.Playoffs) != 6 || len(east.PlayIn) != 4 || len(east.Out) != 5 {
+		t.Fatalf("unexpected split: %+v", east)
+	}
+
+	bos, nyk := east.Playoffs[0], east.Playoffs[1]
+	if bos.Tricode != "BOS" || bos.Marker != ClinchedConference || nyk.Tricode != "NYK" || nyk.Marker != ClinchedPlayoffs {
+		t.Fatalf("expected z BOS then x NYK, got %s %s, %s %s", bos.Marker, bos.Tricode, nyk.Marker, nyk.Tricode)
+	}
+	if seeds := fmt.Sprint(east.PlayIn[0].Seed, east.Out[4].Seed); seeds != "7 15" {
+		t.Fatalf("seeds %s", seeds)
+	}
+	for _, e := range append(east.PlayIn, east.Out...) {
+		if e.Marker != "" {
+			t.Errorf("%s is marked %q with the rest of the East still tied", e.Tricode, e.Marker)
+		}
+		if e.Tricode == "WAS" && e.GamesLeft != 1 {
+			t.Errorf("WAS has %d games left, want 1", e.GamesLeft)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+// Package standings computes league standings from final scores alone, so
+// they need nothing but the season schedule and work from a cached copy.
+// Teams with the same winning percentage are ordered by the NBA's
+// tiebreaker rules, and each tie broken is explained.
+package standings
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+// Result is a regular season game: a final score, or for Remaining a game
+// still to be played.
+type Result struct {
+	GameID    string
+	Date      time.Time
+	Home      string // tricode
+	Away      string
+	HomeScore int
+	AwayScore int
+}
+
+// Winner returns the winning team's tricode.
+func (r Result) Winner() string {
+	if r.HomeScore > r.AwayScore {
+		return r.Home
+	}
+	return r.Away
+}
+
+// Results returns the schedule's completed regular season games, oldest
+// first. Games involving teams outside the league, such as exhibitions
+// against international clubs, are skipped.
+func Results(schedule *nba.LeagueScheduleResponse) []Result {
+	return regularSeason(schedule, true)
+}
+
+// Remaining returns the regular season games still to be played, oldest
+// first, without scores.
+func Remaining(schedule *nba.LeagueScheduleResponse) []Result {
+	return regularSeason(schedule, false)
+}
+
+func regularSeason(schedule *nba.LeagueScheduleResponse, final bool) []Result {
+	var results []Result
+	for _, gameDate := range schedule.LeagueSchedule.GameDates {
+		for _, g := range gameDate.Games {
+			if g.State().IsFinal() != final || !g.IsRegularSeason() {
+				continue
+			}
+			if nba.TeamByTricode(g.HomeTeam.Tricode) == nil || nba.TeamByTricode(g.AwayTeam.Tricode) == nil {
+				continue
+			}
+			date, _ := time.Parse(time.RFC3339, g.StartTimeUTC())
+			r := Result{GameID: g.ID, Date: date, Home: g.HomeTeam.Tricode, Away: g.AwayTeam.Tricode}
+			if final {
+				r.HomeScore, r.AwayScore = g.HomeTeam.Score, g.AwayTeam.Score
+			}
+			results = append(results, r)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if !results[i].Date.Equal(results[j].Date) {
+			return results[i].Date.Before(results[j].Date)
+		}
+		return results[i].GameID < results[j].GameID
+	})
+	return results
+}
+
+// Team is one row of the standings.
+type Team struct {
+	Tricode    string `json:"team"`
+	Name       string `json:"name"`
+	Conference string `json:"conference"`
+	Division   string `json:"division"`
+	// Rank is the team's place in its group, from 1.
+	Rank      int     `json:"rank"`
+	Wins      int     `json:"wins"`
+	Losses    int     `json:"losses"`
+	Pct       float64 `json:"pct"`
+	GamesBack float64 `json:"games_back"`
+
+	Home             nba.Record `json:"home"`
+	Road             nba.Record `json:"road"`
+	ConferenceRecord nba.Record `json:"conference_record"`
+	DivisionRecord   nba.Record `json:"division_record"`
+	LastTen          nba.Record `json:"last_10"`
+	// Streak is the current run of wins or losses, like "W3" or "L1".
+	Streak string `json:"streak"`
+
+	PointsFor     int `json:"points_for"`
+	PointsAgainst int `json:"points_against"`
+
+	// recent holds the team's results, newest last, for the streak and the
+	// last ten.
+	recent []bool
+}
+
+// Record returns the team's overall record.
+func (t Team) Record() nba.Record {
+	return nba.Record{Wins: t.Wins, Losses: t.Losses}
+}
+
+// PointDiff returns points scored minus points allowed.
+func (t Team) PointDiff() int {
+	return t.PointsFor - t.PointsAgainst
+}
+
+// Group is a table of teams: the league, a conference or a division.
+type Group struct {
+	Name      string     `json:"name"`
+	Teams     []Team     `json:"teams"`
+	Tiebreaks []Tiebreak `json:"tiebreaks,omitempty"`
+}
+
+// View selects how the standings are grouped.
+type View string
+
+const (
+	League     View = "league"
+	Conference View = "conference"
+	Division   View = "division"
+)
+
+// Compute returns every team's record from results, keyed by tricode. Teams
+// without a game yet are included at 0-0.
+func Compute(results []Result) map[string]*Team {
+	teams := make(map[string]*Team, len(nba.Teams))
+	for _, f := range nba.Teams {
+		teams[f.Tricode] = &Team{Tricode: f.Tricode, Name: f.FullName(), Conference: f.Conference, Division: f.Division}
+	}
+
+	for _, r := range results {
+		home, away := teams[r.Home], teams[r.Away]
+		if home == nil || away == nil {
+			continue
+		}
+		homeWon := r.HomeScore > r.AwayScore
+		home.record(away, homeWon, r.HomeScore, r.AwayScore)
+		away.record(home, !homeWon, r.AwayScore, r.HomeScore)
+		home.Home.Add(homeWon)
+		away.Road.Add(!homeWon)
+	}
+
+	for _, t := range teams {
+		t.Pct = t.Record().Pct()
+		t.finish()
+	}
+	return teams
+}
+
+// record counts one game against opponent.
+func (t *Team) record(opponent *Team, won bool, scored, allowed int) {
+	if won {
+		t.Wins++
+	} else {
+		t.Losses++
+	}
+	if opponent.Conference == t.Conference {
+		t.ConferenceRecord.Add(won)
+	}
+	if opponent.Division == t.Division {
+		t.DivisionRecord.Add(won)
+	}
+	t.PointsFor += scored
+	t.PointsAgainst += allowed
+	t.recent = append(t.recent, won)
+}
+
+// finish fills in the streak and last ten from the team's results.
+func (t *Team) finish() {
+	for i := len(t.recent) - 1; i >= 0 && i >= len(t.recent)-10; i-- {
+		t.LastTen.Add(t.recent[i])
+	}
+
+	n := 0
+	for i := len(t.recent) - 1; i >= 0 && t.recent[i] == t.recent[len(t.recent)-1]; i-- {
+		n++
+	}
+	switch {
+	case n == 0:
+		t.Streak = "-"
+	case t.recent[len(t.recent)-1]:
+		t.Streak = "W" + strconv.Itoa(n)
+	default:
+		t.Streak = "L" + strconv.Itoa(n)
+	}
+}
+
+// Standings returns results grouped by view, each group ordered by winning
+// percentage and then the tiebreakers, with games back measured from its
+// leader. The East's groups come first, and divisions are alphabetical
+// within a conference.
+func Standings(results []Result, view View) []Group {
+	teams := Compute(results)
+	tb := newTiebreaker(teams, results)
+
+	var groups []Group
+	index := map[string]int{}
+	for _, f := range nba.Teams {
+		name := "League"
+		switch view {
+		case Conference:
+			name = f.Conference
+		case Division:
+			name = f.Division
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, Group{Name: name})
+		}
+		groups[i].Teams = append(groups[i].Teams, *teams[f.Tricode])
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i].Teams[0].Conference, groups[j].Teams[0].Conference
+		if a != b {
+			return a == "East"
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	for i := range groups {
+		tb.rank(&groups[i])
+	}
+	return groups
+}
+
+// rank puts g's teams in order and sets their Rank and GamesBack.
+func (tb *tiebreaker) rank(g *Group) {
+	tricodes := make([]string, len(g.Teams))
+	for i, t := range g.Teams {
+		tricodes[i] = t.Tricode
+	}
+	var order []string
+	order, g.Tiebreaks = tb.order(tricodes)
+
+	leader := tb.teams[order[0]].Record()
+	g.Teams = g.Teams[:0]
+	for i, tricode := range order {
+		t := *tb.teams[tricode]
+		t.Rank = i + 1
+		t.GamesBack = GamesBack(leader, t.Record())
+		g.Teams = append(g.Teams, t)
+	}
+}
+
+// GamesBack returns how many games r trails leader by.
+func GamesBack(leader, r nba.Record) float64 {
+	return float64((leader.Wins-r.Wins)+(r.Losses-leader.Losses)) / 2
+}
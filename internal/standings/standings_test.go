@@ -0,0 +1,134 @@
+package standings
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+var day = time.Date(2024, 10, 22, 0, 0, 0, 0, time.UTC)
+
+// game returns a final with the winner listed first.
+func game(n int, winner, loser string, home bool) Result {
+	r := Result{GameID: fmt.Sprintf("00224%05d", n), Date: day.AddDate(0, 0, n)}
+	if home {
+		r.Home, r.Away, r.HomeScore, r.AwayScore = winner, loser, 110, 100
+	} else {
+		r.Home, r.Away, r.HomeScore, r.AwayScore = loser, winner, 100, 110
+	}
+	return r
+}
+
+func TestResults(t *testing.T) {
+	var schedule nba.LeagueScheduleResponse
+	err := json.Unmarshal([]byte(`{"leagueSchedule":{"gameDates":[
+		{"gameDate":"10/10/2024 00:00:00","games":[
+			{"gameId":"0012400010","gameStatus":3,"homeTeam":{"teamTricode":"NYK","score":120},"awayTeam":{"teamTricode":"BOS","score":90}}]},
+		{"gameDate":"10/22/2024 00:00:00","games":[
+			{"gameId":"0022400062","gameStatus":3,"gameDateTimeUTC":"2024-10-23T02:00:00Z","homeTeam":{"teamTricode":"LAL","score":110},"awayTeam":{"teamTricode":"MIN","score":103}},
+			{"gameId":"0022400061","gameStatus":3,"gameDateTimeUTC":"2024-10-22T23:30:00Z","homeTeam":{"teamTricode":"BOS","score":132},"awayTeam":{"teamTricode":"NYK","score":109}},
+			{"gameId":"0022400063","gameStatus":1,"gameDateTimeUTC":"2024-10-23T23:30:00Z","homeTeam":{"teamTricode":"DET"},"awayTeam":{"teamTricode":"IND"}}]}]}}`), &schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := Results(&schedule)
+	if len(results) != 2 || results[0].GameID != "0022400061" || results[1].Winner() != "LAL" {
+		t.Fatalf("expected the two regular season finals in tip-off order, got %+v", results)
+	}
+	remaining := Remaining(&schedule)
+	if len(remaining) != 1 || remaining[0].Home != "DET" || remaining[0].Away != "IND" {
+		t.Fatalf("expected DET-IND left to play, got %+v", remaining)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	results := []Result{
+		game(1, "BOS", "NYK", true),  // division, conference
+		game(2, "BOS", "LAL", false), // non-conference
+		game(3, "MIA", "BOS", true),  // conference, other division
+		game(4, "BOS", "PHI", true),
+		game(5, "BOS", "TOR", false),
+	}
+	teams := Compute(results)
+
+	bos := teams["BOS"]
+	checks := map[string][2]string{
+		"record":     {bos.Record().String(), "4-1"},
+		"home":       {bos.Home.String(), "2-0"},
+		"road":       {bos.Road.String(), "2-1"},
+		"conference": {bos.ConferenceRecord.String(), "3-1"},
+		"division":   {bos.DivisionRecord.String(), "3-0"},
+		"last 10":    {bos.LastTen.String(), "4-1"},
+		"streak":     {bos.Streak, "W2"},
+		"pct":        {fmt.Sprintf("%.3f", bos.Pct), "0.800"},
+		"diff":       {fmt.Sprint(bos.PointDiff()), "30"},
+	}
+	for name, c := range checks {
+		if c[0] != c[1] {
+			t.Errorf("BOS %s = %s, want %s", name, c[0], c[1])
+		}
+	}
+	if teams["NYK"].Streak != "L1" || teams["CHI"].Streak != "-" || teams["CHI"].Record().String() != "0-0" {
+		t.Errorf("NYK streak %s, CHI %s %s", teams["NYK"].Streak, teams["CHI"].Streak, teams["CHI"].Record())
+	}
+}
+
+func TestCompute_LastTen(t *testing.T) {
+	var results []Result
+	for i := 0; i < 12; i++ {
+		results = append(results, game(i, "BOS", "NYK", true))
+	}
+	results = append(results, game(12, "NYK", "BOS", true))
+
+	bos := Compute(results)["BOS"]
+	if bos.LastTen.String() != "9-1" || bos.Streak != "L1" {
+		t.Fatalf("last 10 %s, streak %s", bos.LastTen, bos.Streak)
+	}
+}
+
+func TestStandings(t *testing.T) {
+	results := []Result{
+		game(1, "BOS", "NYK", true),
+		game(2, "BOS", "PHI", true),
+		game(3, "NYK", "PHI", false),
+		game(4, "LAL", "GSW", true),
+	}
+
+	conf := Standings(results, Conference)
+	if len(conf) != 2 || conf[0].Name != "East" || conf[1].Name != "West" || len(conf[0].Teams) != 15 {
+		t.Fatalf("unexpected conference groups %+v", conf)
+	}
+	east := conf[0].Teams
+	if east[0].Tricode != "BOS" || east[1].Tricode != "NYK" || east[1].GamesBack != 1 || east[0].Rank != 1 {
+		t.Fatalf("unexpected East order: %s %.1f, %s %.1f", east[0].Tricode, east[0].GamesBack, east[1].Tricode, east[1].GamesBack)
+	}
+	if last := east[len(east)-1]; last.Tricode != "PHI" || last.GamesBack != 2 {
+		t.Fatalf("PHI should be last, 2 back: %+v", last)
+	}
+
+	div := Standings(results, Division)
+	var names []string
+	for _, g := range div {
+		names = append(names, g.Name)
+	}
+	if fmt.Sprint(names) != "[Atlantic Central Southeast Northwest Pacific Southwest]" {
+		t.Fatalf("division order %v", names)
+	}
+	if len(div[0].Teams) != 5 {
+		t.Fatalf("expected 5 teams in a division, got %d", len(div[0].Teams))
+	}
+
+	if league := Standings(results, League); len(league) != 1 || len(league[0].Teams) != 30 {
+		t.Fatalf("league view should be one table of 30")
+	}
+}
+
+func TestGamesBack(t *testing.T) {
+	if gb := GamesBack(nba.Record{Wins: 50, Losses: 20}, nba.Record{Wins: 47, Losses: 24}); gb != 3.5 {
+		t.Fatalf("GamesBack = %v, want 3.5", gb)
+	}
+}
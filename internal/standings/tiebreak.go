@@ -0,0 +1,261 @@
+package standings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+// PlayoffField is how many teams per conference count as playoff teams for
+// the "record vs playoff teams" tiebreakers: the six seeds plus the four
+// play-in teams.
+const PlayoffField = 10
+
+// Tiebreak explains how teams with the same winning percentage were
+// ordered.
+type Tiebreak struct {
+	// Teams are the tied teams in the order they were placed.
+	Teams  []string `json:"teams"`
+	Record string   `json:"record"`
+	Steps  []Step   `json:"steps"`
+}
+
+// Step is one rule that separated some of the tied teams.
+type Step struct {
+	Rule string `json:"rule"`
+	// Teams are the teams the rule was applied to, best first, and Values
+	// what each of them had under it.
+	Teams  []string `json:"teams"`
+	Values []string `json:"values"`
+}
+
+// String returns e.g. "head-to-head: BOS 3-1, NYK 1-3".
+func (s Step) String() string {
+	parts := make([]string, len(s.Teams))
+	for i, t := range s.Teams {
+		parts[i] = strings.TrimSpace(t + " " + s.Values[i])
+	}
+	return s.Rule + ": " + strings.Join(parts, ", ")
+}
+
+// Rules, in the order the NBA applies them to two tied teams. With three or
+// more, DivisionLeader comes first and HeadToHead second. DivisionRecord only
+// applies when all the tied teams share a division, and Drawing stands in
+// for the league's random drawing when nothing else separates them.
+const (
+	HeadToHead         = "head-to-head"
+	DivisionLeader     = "division leader"
+	DivisionRecord     = "division record"
+	ConferenceRecord   = "conference record"
+	PlayoffsConference = "vs playoff teams, own conference"
+	PlayoffsOther      = "vs playoff teams, other conference"
+	PointDifferential  = "point differential"
+	Drawing            = "drawing (alphabetical)"
+)
+
+var (
+	twoTeamRules   = []string{HeadToHead, DivisionLeader, DivisionRecord, ConferenceRecord, PlayoffsConference, PlayoffsOther, PointDifferential}
+	multiTeamRules = []string{DivisionLeader, HeadToHead, DivisionRecord, ConferenceRecord, PlayoffsConference, PlayoffsOther, PointDifferential}
+)
+
+// tiebreaker holds what the rules look at besides the tied teams
+// themselves.
+type tiebreaker struct {
+	teams   map[string]*Team
+	results []Result
+	leaders map[string]bool // division leaders; nil while they are being decided
+	playoff map[string]bool
+}
+
+// newTiebreaker prepares the rules for results: it decides the playoff
+// field and then each division's leader, itself by tiebreak if needed.
+func newTiebreaker(teams map[string]*Team, results []Result) *tiebreaker {
+	tb := &tiebreaker{teams: teams, results: results, playoff: map[string]bool{}}
+
+	conferences := map[string][]*Team{}
+	divisions := map[string][]string{}
+	for _, t := range teams {
+		conferences[t.Conference] = append(conferences[t.Conference], t)
+		divisions[t.Division] = append(divisions[t.Division], t.Tricode)
+	}
+
+	// Teams tied with the last team in the field are all in it.
+	for _, list := range conferences {
+		sort.Slice(list, func(i, j int) bool { return list[i].Pct > list[j].Pct })
+		if len(list) < PlayoffField {
+			continue
+		}
+		cutoff := list[PlayoffField-1].Pct
+		for _, t := range list {
+			if t.Pct >= cutoff {
+				tb.playoff[t.Tricode] = true
+			}
+		}
+	}
+
+	leaders := map[string]bool{}
+	for _, division := range divisions {
+		order, _ := tb.order(division)
+		leaders[order[0]] = true
+	}
+	tb.leaders = leaders
+	return tb
+}
+
+// order sorts tricodes by winning percentage, breaking ties, and explains
+// each tie it broke.
+func (tb *tiebreaker) order(tricodes []string) ([]string, []Tiebreak) {
+	sorted := append([]string(nil), tricodes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return tb.teams[sorted[i]].Pct > tb.teams[sorted[j]].Pct
+	})
+
+	var order []string
+	var ties []Tiebreak
+	for start := 0; start < len(sorted); {
+		end := start + 1
+		for end < len(sorted) && tb.teams[sorted[end]].Pct == tb.teams[sorted[start]].Pct {
+			end++
+		}
+		if end-start == 1 {
+			order = append(order, sorted[start])
+		} else {
+			var steps []Step
+			placed := tb.breakTie(sorted[start:end], &steps)
+			order = append(order, placed...)
+			ties = append(ties, Tiebreak{Teams: placed, Record: tb.teams[placed[0]].Record().String(), Steps: steps})
+		}
+		start = end
+	}
+	return order, ties
+}
+
+// breakTie orders teams that are tied, recording each rule that separated
+// any of them. Whenever a rule splits the tie, each smaller tie left over
+// starts again from the first rule of its own size's procedure.
+func (tb *tiebreaker) breakTie(tied []string, steps *[]Step) []string {
+	rules := twoTeamRules
+	if len(tied) > 2 {
+		rules = multiTeamRules
+	}
+
+	for _, rule := range rules {
+		if !tb.applies(rule, tied) {
+			continue
+		}
+		scores := make(map[string]float64, len(tied))
+		values := make(map[string]string, len(tied))
+		for _, t := range tied {
+			scores[t], values[t] = tb.score(rule, t, tied)
+		}
+
+		ranked := append([]string(nil), tied...)
+		sort.SliceStable(ranked, func(i, j int) bool { return scores[ranked[i]] > scores[ranked[j]] })
+		if scores[ranked[0]] == scores[ranked[len(ranked)-1]] {
+			continue
+		}
+
+		step := Step{Rule: rule, Teams: ranked}
+		for _, t := range ranked {
+			step.Values = append(step.Values, values[t])
+		}
+		*steps = append(*steps, step)
+
+		var order []string
+		for start := 0; start < len(ranked); {
+			end := start + 1
+			for end < len(ranked) && scores[ranked[end]] == scores[ranked[start]] {
+				end++
+			}
+			if end-start == 1 {
+				order = append(order, ranked[start])
+			} else {
+				order = append(order, tb.breakTie(ranked[start:end], steps)...)
+			}
+			start = end
+		}
+		return order
+	}
+
+	order := append([]string(nil), tied...)
+	sort.Strings(order)
+	*steps = append(*steps, Step{Rule: Drawing, Teams: order, Values: make([]string, len(order))})
+	return order
+}
+
+// applies reports whether rule can be used for these tied teams.
+func (tb *tiebreaker) applies(rule string, tied []string) bool {
+	switch rule {
+	case DivisionLeader:
+		// Leaders are unknown while a division's own order is being
+		// decided, and teams from one division can't differ on it.
+		return tb.leaders != nil && !tb.sameDivision(tied)
+	case DivisionRecord:
+		return tb.sameDivision(tied)
+	}
+	return true
+}
+
+func (tb *tiebreaker) sameDivision(tied []string) bool {
+	for _, t := range tied[1:] {
+		if tb.teams[t].Division != tb.teams[tied[0]].Division {
+			return false
+		}
+	}
+	return true
+}
+
+// score returns a team's standing under rule, higher being better, and how
+// to show it.
+func (tb *tiebreaker) score(rule, tricode string, tied []string) (float64, string) {
+	team := tb.teams[tricode]
+	switch rule {
+	case HeadToHead:
+		opponents := map[string]bool{}
+		for _, t := range tied {
+			opponents[t] = t != tricode
+		}
+		r := tb.recordAgainst(tricode, func(o *Team) bool { return opponents[o.Tricode] })
+		return r.Pct(), r.String()
+	case DivisionLeader:
+		if tb.leaders[tricode] {
+			return 1, "yes"
+		}
+		return 0, "no"
+	case DivisionRecord:
+		return team.DivisionRecord.Pct(), team.DivisionRecord.String()
+	case ConferenceRecord:
+		return team.ConferenceRecord.Pct(), team.ConferenceRecord.String()
+	case PlayoffsConference, PlayoffsOther:
+		own := rule == PlayoffsConference
+		r := tb.recordAgainst(tricode, func(o *Team) bool {
+			return tb.playoff[o.Tricode] && (o.Conference == team.Conference) == own
+		})
+		return r.Pct(), r.String()
+	case PointDifferential:
+		return float64(team.PointDiff()), fmt.Sprintf("%+d", team.PointDiff())
+	}
+	return 0, ""
+}
+
+// recordAgainst returns a team's record in games against opponents that
+// match.
+func (tb *tiebreaker) recordAgainst(tricode string, match func(*Team) bool) (r nba.Record) {
+	for _, res := range tb.results {
+		var opponent string
+		switch tricode {
+		case res.Home:
+			opponent = res.Away
+		case res.Away:
+			opponent = res.Home
+		default:
+			continue
+		}
+		if o := tb.teams[opponent]; o != nil && o.Tricode != tricode && match(o) {
+			r.Add(res.Winner() == tricode)
+		}
+	}
+	return r
+}
@@ -0,0 +1,159 @@
+package standings
+
+import (
+	"fmt"
+	"testing"
+)
+
+// final returns a result in which winner beat loser by margin at home.
+func final(n int, winner, loser string, margin int) Result {
+	return Result{GameID: fmt.Sprintf("00224%05d", n), Date: day.AddDate(0, 0, n),
+		Home: winner, Away: loser, HomeScore: 100 + margin, AwayScore: 100}
+}
+
+// breakTies ranks the given teams and returns the order and explanations.
+func breakTies(results []Result, tricodes ...string) ([]string, []Tiebreak) {
+	tb := newTiebreaker(Compute(results), results)
+	return tb.order(tricodes)
+}
+
+func TestTiebreak_HeadToHead(t *testing.T) {
+	results := []Result{
+		final(1, "BOS", "NYK", 5),
+		final(2, "NYK", "PHI", 5),
+		final(3, "NYK", "TOR", 5),
+		final(4, "MIA", "BOS", 5),
+		final(5, "BOS", "BKN", 5),
+	}
+	order, ties := breakTies(results, "NYK", "BOS")
+	if fmt.Sprint(order) != "[BOS NYK]" {
+		t.Fatalf("order %v", order)
+	}
+	if len(ties) != 1 || ties[0].Record != "2-1" || len(ties[0].Steps) != 1 {
+		t.Fatalf("ties %+v", ties)
+	}
+	if got := ties[0].Steps[0].String(); got != "head-to-head: BOS 1-0, NYK 0-1" {
+		t.Fatalf("step %q", got)
+	}
+}
+
+func TestTiebreak_DivisionLeader(t *testing.T) {
+	results := []Result{
+		final(1, "CHI", "DET", 5),
+		final(2, "CHI", "IND", 5), // CHI leads the Central
+		final(3, "MIL", "CLE", 5),
+		final(4, "MIA", "MIL", 5),
+		final(5, "BOS", "NYK", 5), // BOS leads the Atlantic
+		final(6, "ORL", "BOS", 5),
+	}
+	order, ties := breakTies(results, "MIL", "BOS")
+	if fmt.Sprint(order) != "[BOS MIL]" || ties[0].Steps[0].Rule != DivisionLeader {
+		t.Fatalf("order %v, ties %+v", order, ties)
+	}
+}
+
+func TestTiebreak_MultiTeamFallsThrough(t *testing.T) {
+	// A circle of wins within the Atlantic leaves everything even until
+	// point differential.
+	results := []Result{
+		final(1, "BOS", "NYK", 10),
+		final(2, "NYK", "PHI", 5),
+		final(3, "PHI", "BOS", 1),
+	}
+	order, ties := breakTies(results, "NYK", "PHI", "BOS")
+	if fmt.Sprint(order) != "[BOS PHI NYK]" {
+		t.Fatalf("order %v", order)
+	}
+	steps := ties[0].Steps
+	if len(steps) != 1 || steps[0].String() != "point differential: BOS +9, PHI -4, NYK -5" {
+		t.Fatalf("steps %v", steps)
+	}
+}
+
+func TestTiebreak_MultiTeamRestartsAsTwoTeam(t *testing.T) {
+	results := []Result{
+		final(1, "CHI", "MIA", 5),
+		final(2, "MIA", "ATL", 5),
+		final(3, "MIA", "ORL", 5),
+		final(4, "CHI", "DET", 5),
+		final(5, "IND", "CHI", 5), // IND leads the Central
+		final(6, "BOS", "NYK", 5),
+		final(7, "BOS", "PHI", 5), // BOS leads the Atlantic
+		final(8, "WAS", "BOS", 5), // WAS leads the Southeast
+	}
+	order, ties := breakTies(results, "MIA", "CHI", "BOS")
+	if fmt.Sprint(order) != "[BOS CHI MIA]" {
+		t.Fatalf("order %v", order)
+	}
+	steps := ties[0].Steps
+	if len(steps) != 2 || steps[0].Rule != DivisionLeader || steps[1].String() != "head-to-head: CHI 1-0, MIA 0-1" {
+		t.Fatalf("steps %v", steps)
+	}
+}
+
+func TestTiebreak_Drawing(t *testing.T) {
+	order, ties := breakTies(nil, "NYK", "BOS")
+	if fmt.Sprint(order) != "[BOS NYK]" || ties[0].Steps[0].Rule != Drawing {
+		t.Fatalf("order %v, ties %+v", order, ties)
+	}
+}
+
+func TestTiebreak_PlayoffTeams(t *testing.T) {
+	// BOS and NYK split their games; BOS beat a playoff team, NYK a team
+	// outside the field.
+	var results []Result
+	n := 0
+	add := func(winner, loser string) {
+		n++
+		results = append(results, final(n, winner, loser, 5))
+	}
+	for _, team := range []string{"ATL", "CHI", "CLE", "DET", "IND", "MIA", "MIL", "ORL", "TOR"} {
+		add(team, "WAS")
+		add(team, "CHA")
+	}
+	add("BOS", "NYK")
+	add("NYK", "BOS")
+	add("BOS", "ATL")
+	add("NYK", "WAS")
+	add("CHA", "BOS")
+	add("CHA", "NYK")
+	add("NYK", "BKN")
+	add("BKN", "BOS")
+	add("BOS", "PHI")
+	add("PHI", "NYK")
+
+	tb := newTiebreaker(Compute(results), results)
+	if !tb.playoff["ATL"] || tb.playoff["WAS"] {
+		t.Fatalf("playoff field %v", tb.playoff)
+	}
+	order, ties := tb.order([]string{"NYK", "BOS"})
+	if fmt.Sprint(order) != "[BOS NYK]" {
+		t.Fatalf("order %v, ties %+v", order, ties)
+	}
+	if rule := ties[0].Steps[0].Rule; rule != PlayoffsConference {
+		t.Fatalf("decided by %q, want %q (steps %v)", rule, PlayoffsConference, ties[0].Steps)
+	}
+}
+
+func TestStandings_ExplainsTies(t *testing.T) {
+	results := []Result{
+		final(1, "BOS", "NYK", 5),
+		final(2, "NYK", "PHI", 5),
+		final(3, "NYK", "TOR", 5),
+		final(4, "MIA", "BOS", 5),
+		final(5, "BOS", "BKN", 5),
+	}
+	east := Standings(results, Conference)[0]
+	if east.Teams[1].Tricode != "BOS" || east.Teams[2].Tricode != "NYK" {
+		t.Fatalf("expected BOS ahead of NYK, got %s %s", east.Teams[1].Tricode, east.Teams[2].Tricode)
+	}
+	found := false
+	for _, tie := range east.Tiebreaks {
+		if fmt.Sprint(tie.Teams) == "[BOS NYK]" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("no explanation for BOS-NYK in %+v", east.Tiebreaks)
+	}
+}
@@ -0,0 +1,108 @@
+// Package stream turns scoreboard polls into a feed of game events that any
+// number of subscribers can follow and resume.
+package stream
+
+import (
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+// EventType says what happened in a game.
+type EventType string
+
+const (
+	// Snapshot carries every game on the scoreboard. It is the first event a
+	// new subscriber sees, and what a subscriber gets instead of a resume
+	// when its Last-Event-ID is too old.
+	Snapshot    EventType = "snapshot"
+	ScoreChange EventType = "score"
+	PeriodStart EventType = "period_start"
+	PeriodEnd   EventType = "period_end"
+	// StatusChange is a move between scheduled, live and final.
+	StatusChange EventType = "status"
+	// LeadChange is a different team taking the lead. Ties don't count, so a
+	// lead that goes through a tie and back is not a change.
+	LeadChange EventType = "lead_change"
+)
+
+// Event is one change to one game, or a Snapshot of all of them.
+type Event struct {
+	ID     uint64    `json:"id"`
+	Type   EventType `json:"type"`
+	Time   time.Time `json:"time"`
+	GameID string    `json:"game_id,omitempty"`
+	// Period is the period that started or ended.
+	Period int `json:"period,omitempty"`
+	// HomeDelta and AwayDelta are the points scored since the last poll.
+	HomeDelta int `json:"home_delta,omitempty"`
+	AwayDelta int `json:"away_delta,omitempty"`
+	// PreviousStatus is the status a StatusChange moved away from.
+	PreviousStatus int `json:"previous_status,omitempty"`
+	// Game is the game after the change.
+	Game *nba.Game `json:"game,omitempty"`
+	// Games is the whole scoreboard, for a Snapshot.
+	Games []nba.Game `json:"games,omitempty"`
+}
+
+// gameState is what the hub remembers about a game between polls.
+type gameState struct {
+	game    nba.Game
+	changes *nba.GameTracker
+	ended   int // the last period a PeriodEnd was sent for
+}
+
+// newGameState starts tracking a game from its first appearance, without
+// reporting anything that happened before.
+func newGameState(g nba.Game) *gameState {
+	s := &gameState{game: g, changes: nba.NewGameTracker(g)}
+	if g.PeriodEnded() || g.State().IsFinal() {
+		s.ended = g.Period
+	}
+	return s
+}
+
+// advance returns the events between the remembered snapshot of a game and
+// cur, in the order they happened, and remembers cur.
+func (s *gameState) advance(cur nba.Game) []Event {
+	prev := s.game
+	s.game = cur
+	game := &cur
+
+	var events []Event
+	add := func(e Event) {
+		e.GameID, e.Game = cur.ID, game
+		events = append(events, e)
+	}
+
+	changes := s.changes.Update(cur)
+	if changes.StatusChanged && !cur.State().IsFinal() {
+		add(Event{Type: StatusChange, PreviousStatus: prev.GameStatus})
+	}
+
+	if changes.PeriodChanged && cur.Period > prev.Period {
+		// A poll can miss the break between periods entirely.
+		if prev.Period > 0 && s.ended < prev.Period {
+			add(Event{Type: PeriodEnd, Period: prev.Period})
+		}
+		add(Event{Type: PeriodStart, Period: cur.Period})
+	}
+
+	if changes.HomeDelta != 0 || changes.AwayDelta != 0 {
+		add(Event{Type: ScoreChange, HomeDelta: changes.HomeDelta, AwayDelta: changes.AwayDelta})
+	}
+
+	if changes.LeadChanged {
+		add(Event{Type: LeadChange})
+	}
+
+	if s.ended < cur.Period && (cur.PeriodEnded() || cur.State().IsFinal()) {
+		add(Event{Type: PeriodEnd, Period: cur.Period})
+		s.ended = cur.Period
+	}
+
+	if changes.StatusChanged && cur.State().IsFinal() {
+		add(Event{Type: StatusChange, PreviousStatus: prev.GameStatus})
+	}
+	return events
+}
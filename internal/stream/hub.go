@@ -0,0 +1,191 @@
+package stream
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+const (
+	// DefaultInterval is how often the hub polls while a game is live.
+	DefaultInterval = 10 * time.Second
+	// DefaultIdleInterval is how often it polls otherwise.
+	DefaultIdleInterval = time.Minute
+	// DefaultBacklog is how many events are kept for resuming subscribers.
+	DefaultBacklog = 1000
+)
+
+// subscriberBuffer is how many events a subscriber can fall behind by
+// before it is dropped. A dropped subscriber reconnects and resumes from
+// its last event ID.
+const subscriberBuffer = 64
+
+// Hub polls the scoreboard and fans the resulting events out to every
+// subscriber, so nba.com sees one poller however many clients are
+// listening.
+type Hub struct {
+	// Fetch gets the scoreboard for each poll. It may hand back a copy
+	// shared with other readers, such as the API server's.
+	Fetch        func(context.Context) (*nba.Scoreboard, error)
+	Interval     time.Duration
+	IdleInterval time.Duration
+	Backlog      int
+	// Log receives polling errors; nil discards them.
+	Log *log.Logger
+
+	mu      sync.Mutex
+	lastID  uint64
+	games   map[string]*gameState
+	board   []nba.Game
+	updated time.Time
+	history []Event
+	subs    map[chan Event]struct{}
+}
+
+// NewHub returns a Hub that polls with fetch. Event IDs start from the
+// current time so that IDs from before a restart are never mistaken for new
+// ones.
+func NewHub(fetch func(context.Context) (*nba.Scoreboard, error)) *Hub {
+	return &Hub{
+		Fetch:        fetch,
+		Interval:     DefaultInterval,
+		IdleInterval: DefaultIdleInterval,
+		Backlog:      DefaultBacklog,
+		lastID:       uint64(time.Now().UnixMilli()),
+		games:        map[string]*gameState{},
+		subs:         map[chan Event]struct{}{},
+	}
+}
+
+// Run polls until ctx is cancelled: every Interval while a game is live and
+// every IdleInterval otherwise. Failed polls are logged and retried on the
+// next tick.
+func (h *Hub) Run(ctx context.Context) {
+	for {
+		interval := h.IdleInterval
+		board, err := h.Fetch(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			if h.Log != nil {
+				h.Log.Printf("stream: %v", err)
+			}
+		default:
+			if h.update(board.Scoreboard.Games, time.Now()) {
+				interval = h.Interval
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// update compares games with the previous poll, publishes what changed and
+// reports whether any game is live. A Snapshot is published instead of
+// per-game events for games the hub hasn't seen before, such as on the
+// first poll or when the scoreboard rolls over to a new day.
+func (h *Hub) update(games []nba.Game, now time.Time) (live bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.board, h.updated = games, now
+
+	var events []Event
+	seen := make(map[string]*gameState, len(games))
+	fresh := false
+	for _, g := range games {
+		live = live || g.State().IsLive()
+		state, ok := h.games[g.ID]
+		if !ok {
+			seen[g.ID] = newGameState(g)
+			fresh = true
+			continue
+		}
+		seen[g.ID] = state
+		events = append(events, state.advance(g)...)
+	}
+	h.games = seen
+
+	if fresh {
+		events = append(events, Event{Type: Snapshot, Games: games})
+	}
+	for _, e := range events {
+		h.lastID++
+		e.ID, e.Time = h.lastID, now
+		h.publish(e)
+	}
+	return live
+}
+
+// publish records e and sends it to every subscriber. Subscribers that
+// can't keep up are closed rather than allowed to stall the hub.
+func (h *Hub) publish(e Event) {
+	h.history = append(h.history, e)
+	if n := len(h.history) - h.Backlog; n > 0 {
+		h.history = append(h.history[:0:0], h.history[n:]...)
+	}
+
+	for ch := range h.subs {
+		select {
+		case ch <- e:
+		default:
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribe starts following the hub. If lastEventID names an event still
+// in the backlog, initial holds every event after it; otherwise initial is
+// a Snapshot of the current scoreboard. Later events arrive on events,
+// which is closed if the subscriber falls too far behind. cancel stops the
+// subscription.
+func (h *Hub) Subscribe(lastEventID string) (initial []Event, events <-chan Event, cancel func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if i, ok := h.resumeFrom(lastEventID); ok {
+		initial = append(initial, h.history[i:]...)
+	} else {
+		initial = []Event{{ID: h.lastID, Type: Snapshot, Time: h.updated, Games: h.board}}
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	h.subs[ch] = struct{}{}
+	cancel = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[ch]; ok {
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+	return initial, ch, cancel
+}
+
+// resumeFrom returns the index in history of the first event after id, and
+// false if id isn't an event the backlog can resume from.
+func (h *Hub) resumeFrom(id string) (int, bool) {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || n > h.lastID {
+		return 0, false
+	}
+	if n == h.lastID {
+		return len(h.history), true
+	}
+	for i, e := range h.history {
+		if e.ID == n+1 {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,174 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func game(status, period, home, away int, clock string) nba.Game {
+	return nba.Game{
+		ID:         "0022400061",
+		GameStatus: status,
+		Period:     period,
+		GameClock:  nba.GameClock(clock),
+		HomeTeam:   nba.Team{Tricode: "BOS", Score: home},
+		AwayTeam:   nba.Team{Tricode: "NYK", Score: away},
+	}
+}
+
+func types(events []Event) []EventType {
+	var out []EventType
+	for _, e := range events {
+		out = append(out, e.Type)
+	}
+	return out
+}
+
+func TestAdvance(t *testing.T) {
+	s := newGameState(game(1, 0, 0, 0, ""))
+
+	steps := []struct {
+		game nba.Game
+		want []EventType
+	}{
+		{game(2, 1, 0, 0, "PT12M00.00S"), []EventType{StatusChange, PeriodStart}},
+		{game(2, 1, 2, 0, "PT11M30.00S"), []EventType{ScoreChange}},
+		{game(2, 1, 2, 2, "PT11M00.00S"), []EventType{ScoreChange}},
+		{game(2, 1, 2, 5, "PT10M30.00S"), []EventType{ScoreChange, LeadChange}},
+		{game(2, 1, 20, 18, "PT00M00.00S"), []EventType{ScoreChange, LeadChange, PeriodEnd}},
+		{game(2, 1, 20, 18, "PT00M00.00S"), nil},
+		{game(2, 2, 22, 18, "PT11M40.00S"), []EventType{PeriodStart, ScoreChange}},
+		// The end of the 2nd and the start of the 3rd fell between polls.
+		{game(2, 3, 50, 44, "PT11M00.00S"), []EventType{PeriodEnd, PeriodStart, ScoreChange}},
+		{game(3, 4, 101, 99, ""), []EventType{PeriodEnd, PeriodStart, ScoreChange, PeriodEnd, StatusChange}},
+	}
+	for i, step := range steps {
+		got := s.advance(step.game)
+		if fmt.Sprint(types(got)) != fmt.Sprint(step.want) {
+			t.Fatalf("step %d: events = %v, want %v", i, types(got), step.want)
+		}
+	}
+}
+
+func TestAdvance_Details(t *testing.T) {
+	s := newGameState(game(2, 2, 40, 40, "PT05M00.00S"))
+	events := s.advance(game(2, 2, 43, 41, "PT04M30.00S"))
+	if len(events) != 1 || events[0].HomeDelta != 3 || events[0].AwayDelta != 1 {
+		t.Fatalf("events = %+v", events)
+	}
+	if events[0].GameID != "0022400061" || events[0].Game.HomeTeam.Score != 43 {
+		t.Fatalf("event should carry the updated game: %+v", events[0])
+	}
+
+	s = newGameState(game(2, 4, 99, 98, "PT00M30.00S"))
+	events = s.advance(game(3, 4, 101, 98, ""))
+	if len(events) != 3 || events[1].Period != 4 || events[2].PreviousStatus != 2 {
+		t.Fatalf("events = %+v", events)
+	}
+}
+
+func newTestHub() *Hub {
+	h := NewHub(nil)
+	h.Backlog = 3
+	h.lastID = 100
+	return h
+}
+
+func TestHub_Update(t *testing.T) {
+	h := newTestHub()
+	now := time.Date(2024, 11, 1, 23, 0, 0, 0, time.UTC)
+
+	initial, events, cancel := h.Subscribe("")
+	defer cancel()
+	if len(initial) != 1 || initial[0].Type != Snapshot || initial[0].ID != 100 {
+		t.Fatalf("initial = %+v", initial)
+	}
+
+	if live := h.update([]nba.Game{game(2, 1, 0, 0, "PT12M00.00S")}, now); !live {
+		t.Fatalf("update should report a live game")
+	}
+	if e := <-events; e.Type != Snapshot || e.ID != 101 || len(e.Games) != 1 || !e.Time.Equal(now) {
+		t.Fatalf("first poll should publish a snapshot, got %+v", e)
+	}
+
+	if live := h.update([]nba.Game{game(3, 1, 0, 0, "")}, now); live {
+		t.Fatalf("update should report no live game")
+	}
+	for _, want := range []EventType{PeriodEnd, StatusChange} {
+		if e := <-events; e.Type != want {
+			t.Fatalf("event = %+v, want %s", e, want)
+		}
+	}
+}
+
+func TestHub_Resume(t *testing.T) {
+	h := newTestHub()
+	h.update([]nba.Game{game(2, 1, 0, 0, "PT12M00.00S")}, time.Now()) // 101 snapshot
+	for score := 2; score <= 8; score += 2 {
+		h.update([]nba.Game{game(2, 1, score, 0, "PT11M00.00S")}, time.Now()) // 102-105
+	}
+
+	cases := []struct {
+		lastID string
+		want   []uint64
+	}{
+		{"103", []uint64{104, 105}},
+		{"102", []uint64{103, 104, 105}},
+		{"105", nil},
+		// Too old for the backlog, from the future, or not an ID at all.
+		{"101", []uint64{105}},
+		{"900", []uint64{105}},
+		{"abc", []uint64{105}},
+	}
+	for _, c := range cases {
+		initial, _, cancel := h.Subscribe(c.lastID)
+		cancel()
+		var got []uint64
+		for _, e := range initial {
+			got = append(got, e.ID)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(c.want) {
+			t.Errorf("Subscribe(%q) IDs = %v, want %v", c.lastID, got, c.want)
+		}
+		if len(c.want) == 1 && initial[0].Type != Snapshot {
+			t.Errorf("Subscribe(%q) should start with a snapshot", c.lastID)
+		}
+	}
+}
+
+func TestHub_DropsSlowSubscriber(t *testing.T) {
+	h := newTestHub()
+	h.Backlog = 1000
+	h.update([]nba.Game{game(2, 1, 0, 0, "PT12M00.00S")}, time.Now())
+
+	_, events, cancel := h.Subscribe("")
+	defer cancel()
+	for score := 1; score <= subscriberBuffer+1; score++ {
+		h.update([]nba.Game{game(2, 1, score, 0, "PT11M00.00S")}, time.Now())
+	}
+
+	n := 0
+	for range events {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Fatalf("received %d events before being dropped, want %d", n, subscriberBuffer)
+	}
+}
+
+func TestEvent_JSONFieldNames(t *testing.T) {
+	data, err := json.Marshal(Event{ID: 1, Type: ScoreChange, GameID: "0022400061", HomeDelta: 3, AwayDelta: 2, PreviousStatus: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"game_id":`, `"home_delta":`, `"away_delta":`, `"previous_status":`} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("expected %s in %s", want, data)
+		}
+	}
+}
@@ -0,0 +1,393 @@
+// Package tui is the full-screen dashboard behind `bball tui`. It draws with
+// plain ANSI escape sequences so it works in any xterm-compatible terminal,
+// including over SSH.
+package tui
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+const (
+	// DefaultLiveInterval is how often the scoreboard is refetched while a
+	// game is in progress.
+	DefaultLiveInterval = 15 * time.Second
+	// DefaultIdleInterval is how often it is refetched otherwise.
+	DefaultIdleInterval = time.Minute
+
+	// resizePoll is how often the terminal size is checked. Polling works
+	// everywhere, unlike SIGWINCH.
+	resizePoll = 250 * time.Millisecond
+)
+
+// Options configures the dashboard.
+type Options struct {
+	Client *nba.Client
+	// Favorite is the team the schedule tab opens on. When nil it opens on
+	// the selected game's home team.
+	Favorite     *nba.Franchise
+	LiveInterval time.Duration
+	IdleInterval time.Duration
+}
+
+type view int
+
+const (
+	gamesView view = iota
+	detailView
+	scheduleView
+)
+
+// action is work the event loop has to do after a keypress.
+type action int
+
+const (
+	noAction action = iota
+	quitAction
+	refreshAction  // refetch today's scoreboard
+	scheduleAction // fetch the schedule for model.team and model.mode
+)
+
+// model is the dashboard's state. Only the event loop touches it.
+type model struct {
+	view     view
+	games    []nba.Game
+	selected int
+	gridTop  int // first visible row of cards
+	scroll   int // first visible line of the detail and schedule views
+
+	favorite *nba.Franchise
+	team     *nba.Franchise // whose schedule the schedule view shows
+	mode     string         // "upcoming" or "recent"
+	schedule []nba.Game
+	loaded   string // team and mode the schedule was fetched for
+
+	loading bool
+	updated time.Time
+	fed     time.Time // when the CDN last wrote the scoreboard
+	err     error
+
+	width, height int
+}
+
+func newModel(favorite *nba.Franchise) *model {
+	return &model{favorite: favorite, mode: "upcoming", width: 80, height: 24}
+}
+
+// setGames replaces the scoreboard, keeping the same game selected.
+func (m *model) setGames(games []nba.Game) {
+	id := ""
+	if g, ok := m.selectedGame(); ok {
+		id = g.ID
+	}
+
+	m.games = games
+	m.selected = 0
+	for i, g := range games {
+		if g.ID == id {
+			m.selected = i
+		}
+	}
+}
+
+func (m *model) selectedGame() (nba.Game, bool) {
+	if m.selected < 0 || m.selected >= len(m.games) {
+		return nba.Game{}, false
+	}
+	return m.games[m.selected], true
+}
+
+// anyLive reports whether a game on the scoreboard is in progress.
+func (m *model) anyLive() bool {
+	for _, g := range m.games {
+		if g.State().IsLive() {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleKey identifies the schedule the schedule view should show.
+func (m *model) scheduleKey() string {
+	if m.team == nil {
+		return ""
+	}
+	return m.team.Tricode + "/" + m.mode
+}
+
+func (m *model) update(k key) action {
+	if k.code == keyCtrlC || k.code == keyRune && k.r == 'q' {
+		return quitAction
+	}
+
+	switch m.view {
+	case gamesView:
+		return m.updateGames(k)
+	case detailView:
+		return m.updateDetail(k)
+	default:
+		return m.updateSchedule(k)
+	}
+}
+
+func (m *model) updateGames(k key) action {
+	cols := gridColumns(m.width)
+	switch {
+	case k.code == keyLeft || k.code == keyRune && k.r == 'h':
+		m.moveSelection(-1)
+	case k.code == keyRight || k.code == keyRune && k.r == 'l':
+		m.moveSelection(1)
+	case k.code == keyUp || k.code == keyRune && k.r == 'k':
+		m.moveSelection(-cols)
+	case k.code == keyDown || k.code == keyRune && k.r == 'j':
+		m.moveSelection(cols)
+	case k.code == keyHome:
+		m.selected = 0
+	case k.code == keyEnd:
+		m.selected = len(m.games) - 1
+	case k.code == keyEnter:
+		if _, ok := m.selectedGame(); ok {
+			m.view, m.scroll = detailView, 0
+		}
+	case k.code == keyTab:
+		return m.openSchedule()
+	case k.code == keyRune && k.r == 'r':
+		return refreshAction
+	}
+	return noAction
+}
+
+func (m *model) updateDetail(k key) action {
+	switch {
+	case k.code == keyEsc || k.code == keyBackspace:
+		m.view = gamesView
+	case k.code == keyLeft || k.code == keyRune && k.r == 'h':
+		m.moveSelection(-1)
+		m.scroll = 0
+	case k.code == keyRight || k.code == keyRune && k.r == 'l':
+		m.moveSelection(1)
+		m.scroll = 0
+	case k.code == keyTab:
+		return m.openSchedule()
+	case k.code == keyRune && k.r == 'r':
+		return refreshAction
+	default:
+		m.scrollBy(k)
+	}
+	return noAction
+}
+
+func (m *model) updateSchedule(k key) action {
+	switch {
+	case k.code == keyEsc || k.code == keyBackspace || k.code == keyTab:
+		m.view = gamesView
+	case k.code == keyRune && k.r == 'a':
+		if g, ok := m.selectedGame(); ok {
+			return m.showSchedule(nba.TeamByID(g.AwayTeam.ID))
+		}
+	case k.code == keyRune && k.r == 'h':
+		if g, ok := m.selectedGame(); ok {
+			return m.showSchedule(nba.TeamByID(g.HomeTeam.ID))
+		}
+	case k.code == keyRune && k.r == 'f':
+		return m.showSchedule(m.favorite)
+	case k.code == keyRune && k.r == 'm':
+		if m.mode == "upcoming" {
+			m.mode = "recent"
+		} else {
+			m.mode = "upcoming"
+		}
+		m.scroll = 0
+		return scheduleAction
+	case k.code == keyRune && k.r == 'r':
+		m.loaded = ""
+		return scheduleAction
+	default:
+		m.scrollBy(k)
+	}
+	return noAction
+}
+
+// openSchedule switches to the schedule view, picking the favorite team or
+// else the selected game's home team the first time.
+func (m *model) openSchedule() action {
+	m.view, m.scroll = scheduleView, 0
+	if m.team == nil {
+		m.team = m.favorite
+	}
+	if m.team == nil {
+		if g, ok := m.selectedGame(); ok {
+			m.team = nba.TeamByID(g.HomeTeam.ID)
+		}
+	}
+	if m.team == nil || m.scheduleKey() == m.loaded {
+		return noAction
+	}
+	return scheduleAction
+}
+
+func (m *model) showSchedule(team *nba.Franchise) action {
+	if team == nil {
+		return noAction
+	}
+	m.team, m.scroll = team, 0
+	if m.scheduleKey() == m.loaded {
+		return noAction
+	}
+	return scheduleAction
+}
+
+func (m *model) moveSelection(delta int) {
+	next := m.selected + delta
+	if next < 0 || next >= len(m.games) {
+		return
+	}
+	m.selected = next
+}
+
+func (m *model) scrollBy(k key) {
+	page := m.bodyHeight()
+	switch {
+	case k.code == keyUp || k.code == keyRune && k.r == 'k':
+		m.scroll--
+	case k.code == keyDown || k.code == keyRune && k.r == 'j':
+		m.scroll++
+	case k.code == keyPageUp:
+		m.scroll -= page
+	case k.code == keyPageDown || k.code == keyRune && k.r == ' ':
+		m.scroll += page
+	case k.code == keyHome:
+		m.scroll = 0
+	}
+	if m.scroll < 0 {
+		m.scroll = 0
+	}
+}
+
+// Run shows the dashboard on the terminal until the user quits or ctx is
+// cancelled.
+func Run(ctx context.Context, in, out *os.File, opts Options) error {
+	if opts.LiveInterval <= 0 {
+		opts.LiveInterval = DefaultLiveInterval
+	}
+	if opts.IdleInterval <= 0 {
+		opts.IdleInterval = DefaultIdleInterval
+	}
+
+	t, err := openTerminal(in, out)
+	if err != nil {
+		return err
+	}
+	defer t.close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	keys := make(chan key)
+	go readKeys(in, keys)
+
+	// Fetches run in the background and hand their result back to the
+	// event loop, which is the only place the model changes.
+	results := make(chan func(m *model))
+	deliver := func(apply func(m *model)) {
+		select {
+		case results <- apply:
+		case <-ctx.Done():
+		}
+	}
+
+	m := newModel(opts.Favorite)
+	m.width, m.height = t.size()
+	var nextRefresh time.Time
+
+	refresh := func() {
+		if m.loading {
+			return
+		}
+		m.loading = true
+		go func() {
+			scoreboard, err := opts.Client.FetchScoreboard(ctx)
+			deliver(func(m *model) {
+				m.loading, m.err = false, err
+				if err == nil {
+					m.setGames(scoreboard.Scoreboard.Games)
+					m.updated = time.Now()
+					m.fed = m.updated
+					if fed, ok := scoreboard.UpdatedAt(); ok {
+						m.fed = fed
+					}
+				}
+				interval := opts.IdleInterval
+				if m.anyLive() {
+					interval = opts.LiveInterval
+				}
+				nextRefresh = time.Now().Add(interval)
+			})
+		}()
+	}
+
+	loadSchedule := func() {
+		team, mode, want := m.team, m.mode, m.scheduleKey()
+		if team == nil {
+			return
+		}
+		m.schedule, m.loaded = nil, ""
+		go func() {
+			games, err := opts.Client.FetchTeamSchedule(ctx, team.Tricode, mode)
+			deliver(func(m *model) {
+				if m.scheduleKey() != want {
+					return // the user moved on to another team
+				}
+				m.err = err
+				if err == nil {
+					m.schedule, m.loaded = games, want
+				}
+			})
+		}()
+	}
+
+	refresh()
+	resize := time.NewTicker(resizePoll)
+	defer resize.Stop()
+	clock := time.NewTicker(time.Second)
+	defer clock.Stop()
+
+	for dirty := true; ; dirty = true {
+		if dirty {
+			t.draw(m.render())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case k, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			switch m.update(k) {
+			case quitAction:
+				return nil
+			case refreshAction:
+				refresh()
+			case scheduleAction:
+				loadSchedule()
+			}
+		case apply := <-results:
+			apply(m)
+		case <-resize.C:
+			w, h := t.size()
+			if w == m.width && h == m.height {
+				dirty = false
+				continue
+			}
+			m.width, m.height = w, h
+		case <-clock.C:
+			if !nextRefresh.IsZero() && time.Now().After(nextRefresh) {
+				refresh()
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func testGames(n int) []nba.Game {
+	games := make([]nba.Game, n)
+	for i := range games {
+		games[i] = nba.Game{
+			ID:         string(rune('a' + i)),
+			GameStatus: 3,
+			HomeTeam:   nba.Team{ID: 1610612738, Tricode: "BOS", Score: 100 + i},
+			AwayTeam:   nba.Team{ID: 1610612752, Tricode: "NYK", Score: 90 + i},
+		}
+	}
+	return games
+}
+
+func runeKey(r rune) key { return key{code: keyRune, r: r} }
+
+func TestModel_GridNavigation(t *testing.T) {
+	m := newModel(nil)
+	m.width = 2*cardWidth + cardGap // two cards per row
+	m.setGames(testGames(5))
+
+	m.update(key{code: keyRight})
+	m.update(key{code: keyDown})
+	if m.selected != 3 {
+		t.Fatalf("selected = %d, want 3", m.selected)
+	}
+	m.update(key{code: keyDown}) // no card below
+	if m.selected != 3 {
+		t.Fatalf("moving past the last row should do nothing, selected = %d", m.selected)
+	}
+	m.update(runeKey('k'))
+	if m.selected != 1 {
+		t.Fatalf("selected = %d, want 1", m.selected)
+	}
+}
+
+func TestModel_SetGamesKeepsSelection(t *testing.T) {
+	m := newModel(nil)
+	games := testGames(3)
+	m.setGames(games)
+	m.selected = 2
+
+	m.setGames([]nba.Game{games[2], games[0]})
+	if g, _ := m.selectedGame(); g.ID != games[2].ID {
+		t.Fatalf("selection moved to %q", g.ID)
+	}
+}
+
+func TestModel_DetailAndBack(t *testing.T) {
+	m := newModel(nil)
+	m.setGames(testGames(2))
+
+	m.update(key{code: keyEnter})
+	if m.view != detailView {
+		t.Fatalf("Enter should open the game, view = %d", m.view)
+	}
+	m.update(key{code: keyEsc})
+	if m.view != gamesView {
+		t.Fatalf("Esc should go back, view = %d", m.view)
+	}
+	if m.update(runeKey('q')) != quitAction || m.update(key{code: keyCtrlC}) != quitAction {
+		t.Fatal("q and Ctrl-C should quit")
+	}
+}
+
+func TestModel_ScheduleTab(t *testing.T) {
+	m := newModel(nil)
+	m.setGames(testGames(1))
+
+	if a := m.update(key{code: keyTab}); a != scheduleAction {
+		t.Fatalf("Tab should load a schedule, got %d", a)
+	}
+	if m.view != scheduleView || m.team == nil || m.team.Tricode != "BOS" {
+		t.Fatalf("without a favorite the home team's schedule opens, team = %v", m.team)
+	}
+
+	m.schedule, m.loaded = testGames(1), m.scheduleKey()
+	if a := m.update(runeKey('a')); a != scheduleAction || m.team.Tricode != "NYK" {
+		t.Fatalf("a should switch to the away team, team = %s", m.team.Tricode)
+	}
+	if a := m.update(runeKey('m')); a != scheduleAction || m.mode != "recent" {
+		t.Fatalf("m should toggle to recent games, mode = %s", m.mode)
+	}
+
+	m.update(key{code: keyTab})
+	if m.view != gamesView {
+		t.Fatal("Tab from the schedule should go back to the games")
+	}
+}
+
+func TestModel_ScheduleTabPrefersFavorite(t *testing.T) {
+	m := newModel(nba.TeamByTricode("LAL"))
+	m.setGames(testGames(1))
+	m.update(key{code: keyTab})
+	if m.team.Tricode != "LAL" {
+		t.Fatalf("team = %s, want the favorite", m.team.Tricode)
+	}
+}
+
+func TestRender_FitsTerminal(t *testing.T) {
+	m := newModel(nil)
+	m.width, m.height = 70, 12
+	m.setGames(testGames(7))
+	m.updated = time.Now()
+	m.fed = m.updated
+
+	for _, v := range []view{gamesView, detailView, scheduleView} {
+		m.view = v
+		lines := m.render()
+		if len(lines) != m.height {
+			t.Fatalf("view %d: %d lines, want %d", v, len(lines), m.height)
+		}
+		for _, line := range lines {
+			if displayWidth(line) > m.width {
+				t.Fatalf("view %d: line wider than the terminal: %q", v, line)
+			}
+		}
+	}
+}
+
+func TestRender_GridScrollsToSelection(t *testing.T) {
+	m := newModel(nil)
+	m.width, m.height = 30, 13 // one column, two rows of cards
+	m.setGames(testGames(6))
+	m.updated = time.Now()
+	m.fed = m.updated
+	m.selected = 5
+
+	screen := strings.Join(m.render(), "\n")
+	if !strings.Contains(screen, "105") || strings.Contains(screen, "100") {
+		t.Fatalf("the selected game should be on screen:\n%s", screen)
+	}
+}
+
+func TestRender_ShowsErrorInFooter(t *testing.T) {
+	m := newModel(nil)
+	m.err = errors.New("failed to fetch games")
+	lines := m.render()
+	if !strings.Contains(lines[len(lines)-1], "failed to fetch games") {
+		t.Fatalf("footer = %q", lines[len(lines)-1])
+	}
+}
+
+func TestCard(t *testing.T) {
+	game := nba.Game{
+		GameStatus: 2, Period: 3, GameClock: "5:21",
+		AwayTeam: nba.Team{Tricode: "NYK", Wins: 10, Losses: 4, Score: 77},
+		HomeTeam: nba.Team{Tricode: "BOS", Wins: 12, Losses: 2, Score: 81},
+		GameLeaders: nba.GameLeaders{
+			HomeLeaders: nba.Leader{Name: "Jayson Tatum", Points: 24},
+			AwayLeaders: nba.Leader{Name: "Jalen Brunson", Points: 27},
+		},
+	}
+
+	lines := card(game, false)
+	want := []string{
+		" LIVE - Q3 5:21",
+		" NYK (10-4)             77",
+		" BOS (12-2)             81",
+		" Jalen Brunson 27 PTS",
+	}
+	for i := range want {
+		if got := strings.TrimRight(lines[i], " "); got != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got, want[i])
+		}
+		if displayWidth(lines[i]) != cardWidth {
+			t.Errorf("line %d is %d cells wide, want %d", i, displayWidth(lines[i]), cardWidth)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+type keyCode int
+
+const (
+	keyRune keyCode = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyHome
+	keyEnd
+	keyPageUp
+	keyPageDown
+	keyEnter
+	keyEsc
+	keyTab
+	keyBackspace
+	keyCtrlC
+	keyUnknown
+)
+
+// key is one keypress read from the terminal in raw mode.
+type key struct {
+	code keyCode
+	r    rune // set when code is keyRune
+}
+
+// csiKeys maps the final byte of a cursor sequence like "\x1b[A" (or the
+// application-mode "\x1bOA") to a key.
+var csiKeys = map[byte]keyCode{
+	'A': keyUp,
+	'B': keyDown,
+	'C': keyRight,
+	'D': keyLeft,
+	'H': keyHome,
+	'F': keyEnd,
+}
+
+// tildeKeys maps the parameter of a sequence like "\x1b[5~" to a key.
+var tildeKeys = map[string]keyCode{
+	"1": keyHome,
+	"7": keyHome,
+	"4": keyEnd,
+	"8": keyEnd,
+	"5": keyPageUp,
+	"6": keyPageDown,
+}
+
+// parseKeys decodes the bytes of one terminal read into keys. A lone ESC at
+// the end of the buffer is the Escape key; xterm sends escape sequences in
+// a single write.
+func parseKeys(b []byte) []key {
+	var keys []key
+	for len(b) > 0 {
+		k, n := parseKey(b)
+		if k.code != keyUnknown {
+			keys = append(keys, k)
+		}
+		b = b[n:]
+	}
+	return keys
+}
+
+func parseKey(b []byte) (key, int) {
+	switch c := b[0]; {
+	case c == 0x1b:
+		return parseEscape(b)
+	case c == '\r' || c == '\n':
+		return key{code: keyEnter}, 1
+	case c == '\t':
+		return key{code: keyTab}, 1
+	case c == 0x7f || c == 0x08:
+		return key{code: keyBackspace}, 1
+	case c == 0x03:
+		return key{code: keyCtrlC}, 1
+	case c < 0x20:
+		return key{code: keyUnknown}, 1
+	}
+
+	r, n := utf8.DecodeRune(b)
+	if r == utf8.RuneError {
+		return key{code: keyUnknown}, n
+	}
+	return key{code: keyRune, r: r}, n
+}
+
+func parseEscape(b []byte) (key, int) {
+	if len(b) < 2 || (b[1] != '[' && b[1] != 'O') {
+		// Escape on its own, or Alt+key which we treat as Escape.
+		return key{code: keyEsc}, 1
+	}
+
+	// Parameters are digits and semicolons, ended by a final byte in 0x40-0x7e.
+	i := 2
+	for i < len(b) && (b[i] >= '0' && b[i] <= '9' || b[i] == ';') {
+		i++
+	}
+	if i == len(b) {
+		return key{code: keyUnknown}, i
+	}
+	final, params := b[i], string(b[2:i])
+	if final < 0x40 || final > 0x7e {
+		return key{code: keyUnknown}, i
+	}
+
+	if final == '~' {
+		if code, ok := tildeKeys[params]; ok {
+			return key{code: code}, i + 1
+		}
+		return key{code: keyUnknown}, i + 1
+	}
+	if code, ok := csiKeys[final]; ok {
+		return key{code: code}, i + 1
+	}
+	return key{code: keyUnknown}, i + 1
+}
+
+// readKeys sends keys read from r until it fails, then closes keys.
+func readKeys(r io.Reader, keys chan<- key) {
+	defer close(keys)
+
+	buf := make([]byte, 256)
+	for {
+		n, err := r.Read(buf)
+		for _, k := range parseKeys(buf[:n]) {
+			keys <- k
+		}
+		if err != nil {
+			return
+		}
+	}
+}
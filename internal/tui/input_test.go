@@ -0,0 +1,25 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	cases := map[string][]key{
+		"q":              {{code: keyRune, r: 'q'}},
+		"\x1b[A\x1b[B":   {{code: keyUp}, {code: keyDown}},
+		"\x1bOC":         {{code: keyRight}},
+		"\x1b[5~\x1b[6~": {{code: keyPageUp}, {code: keyPageDown}},
+		"\x1b":           {{code: keyEsc}},
+		"\r\t\x7f\x03":   {{code: keyEnter}, {code: keyTab}, {code: keyBackspace}, {code: keyCtrlC}},
+		"é":              {{code: keyRune, r: 'é'}},
+		"\x1b[1;5Dx":     {{code: keyLeft}, {code: keyRune, r: 'x'}},
+		"\x1b[200~":      nil,
+	}
+	for in, want := range cases {
+		if got := parseKeys([]byte(in)); !reflect.DeepEqual(got, want) {
+			t.Errorf("parseKeys(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
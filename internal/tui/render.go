@@ -0,0 +1,261 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/util"
+)
+
+const (
+	cardWidth  = 28
+	cardHeight = 4
+	cardGap    = 2
+)
+
+// gridColumns returns how many game cards fit side by side in width cells.
+func gridColumns(width int) int {
+	if cols := (width + cardGap) / (cardWidth + cardGap); cols > 1 {
+		return cols
+	}
+	return 1
+}
+
+// bodyHeight is the number of lines between the header and the footer.
+func (m *model) bodyHeight() int {
+	if h := m.height - 3; h > 1 {
+		return h
+	}
+	return 1
+}
+
+// render returns the whole screen, one string per line, each cut to the
+// terminal width.
+func (m *model) render() []string {
+	var body []string
+	switch m.view {
+	case gamesView:
+		body = m.renderGames()
+	case detailView:
+		body = m.scrolled(m.renderDetail())
+	default:
+		body = m.scrolled(m.renderSchedule())
+	}
+
+	lines := []string{m.renderHeader(), strings.Repeat("─", m.width)}
+	lines = append(lines, body...)
+	for len(lines) < m.height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, m.renderFooter())
+
+	if len(lines) > m.height {
+		lines = lines[len(lines)-m.height:]
+	}
+	for i := range lines {
+		lines[i] = truncate(lines[i], m.width)
+	}
+	return lines
+}
+
+func (m *model) renderHeader() string {
+	bold := color.New(color.Bold).SprintFunc()
+
+	tabs := []string{" Games ", " Schedule "}
+	active := 0
+	if m.view == scheduleView {
+		active = 1
+	}
+	tabs[active] = reverse + tabs[active] + noReverse
+	left := bold("bball") + "  " + strings.Join(tabs, " ")
+
+	var right string
+	switch {
+	case m.loading && m.updated.IsZero():
+		right = "Loading…"
+	case !m.updated.IsZero():
+		right = "Updated " + util.FormatAge(time.Since(m.updated))
+	}
+
+	return fit(left, m.width-displayWidth(right)) + right
+}
+
+func (m *model) renderFooter() string {
+	if m.err != nil {
+		return color.New(color.FgYellow).Sprint("! " + m.err.Error())
+	}
+
+	faint := color.New(color.Faint).SprintFunc()
+	switch m.view {
+	case gamesView:
+		return faint("←↑↓→ move · Enter details · Tab schedule · r refresh · q quit")
+	case detailView:
+		return faint("Esc back · ←→ prev/next game · ↑↓ scroll · Tab schedule · q quit")
+	default:
+		return faint("Esc back · a/h away/home team · f favorite · m upcoming/recent · ↑↓ scroll · q quit")
+	}
+}
+
+// scrolled returns the window of lines starting at the scroll offset,
+// pulling the offset back if the content got shorter.
+func (m *model) scrolled(lines []string) []string {
+	height := m.bodyHeight()
+	if max := len(lines) - height; m.scroll > max {
+		m.scroll = max
+	}
+	if m.scroll < 0 {
+		m.scroll = 0
+	}
+
+	lines = lines[m.scroll:]
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return lines
+}
+
+// renderGames lays today's games out as a grid of cards, scrolling by rows
+// to keep the selected card on screen.
+func (m *model) renderGames() []string {
+	if len(m.games) == 0 {
+		if m.loading || m.updated.IsZero() {
+			return []string{"", "  Loading today's games…"}
+		}
+		return []string{"", "  No games scheduled for today."}
+	}
+
+	cols := gridColumns(m.width)
+	visibleRows := (m.bodyHeight() + 1) / (cardHeight + 1)
+	if visibleRows < 1 {
+		visibleRows = 1
+	}
+	row := m.selected / cols
+	if row < m.gridTop {
+		m.gridTop = row
+	}
+	if row >= m.gridTop+visibleRows {
+		m.gridTop = row - visibleRows + 1
+	}
+
+	var lines []string
+	for r := m.gridTop; r < m.gridTop+visibleRows && r*cols < len(m.games); r++ {
+		if r > m.gridTop {
+			lines = append(lines, "")
+		}
+
+		rowLines := make([]string, cardHeight)
+		for c := 0; c < cols; c++ {
+			i := r*cols + c
+			if i >= len(m.games) {
+				break
+			}
+			for l, text := range card(m.games[i], i == m.selected) {
+				if c > 0 {
+					rowLines[l] += strings.Repeat(" ", cardGap)
+				}
+				rowLines[l] += text
+			}
+		}
+		lines = append(lines, rowLines...)
+	}
+	return lines
+}
+
+// card returns the cardHeight lines of a game's card, each exactly
+// cardWidth cells wide. The selected card is drawn in reverse video.
+func card(game nba.Game, selected bool) []string {
+	lines := []string{
+		" " + util.GameStatusLabel(game),
+		" " + cardTeam(game.AwayTeam, game.State()),
+		" " + cardTeam(game.HomeTeam, game.State()),
+		" " + cardNote(game),
+	}
+
+	for i, line := range lines {
+		line = fit(line, cardWidth)
+		switch {
+		case selected:
+			line = reverse + line + noReverse
+		case i == 0:
+			line = util.ColorStatus(game, line)
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
+// cardTeam returns a team's line like "BOS (12-3)        104".
+func cardTeam(team nba.Team, state nba.GameState) string {
+	record := ""
+	if team.Wins+team.Losses > 0 {
+		record = fmt.Sprintf("(%d-%d)", team.Wins, team.Losses)
+	}
+	score := ""
+	if state.HasScore() {
+		score = fmt.Sprint(team.Score)
+	}
+	return fmt.Sprintf("%-4s%-9s%12s", team.Tricode, record, score)
+}
+
+// cardNote is the last line of a card: tip time and TV before the game,
+// the top scorer once it has started.
+func cardNote(game nba.Game) string {
+	if game.State().IsUpcoming() {
+		note := util.FormatTipTime(game)
+		if tv := util.FormatBroadcasters(game.Broadcasters); tv != "" {
+			note += " · " + tv
+		}
+		return note
+	}
+
+	leader := game.GameLeaders.HomeLeaders
+	if game.GameLeaders.AwayLeaders.Points > leader.Points {
+		leader = game.GameLeaders.AwayLeaders
+	}
+	if leader.Name == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s %d PTS", leader.Name, leader.Points)
+}
+
+// renderDetail shows the selected game's summary, leaders and linescore.
+func (m *model) renderDetail() []string {
+	game, ok := m.selectedGame()
+	if !ok {
+		return nil
+	}
+
+	summary := nba.NewGameSummary(game)
+	summary.SetUpdated(m.fed, time.Now())
+
+	lines := append([]string{""}, splitLines(util.FormatGameSummary(summary))...)
+	if tv := util.FormatBroadcasters(game.Broadcasters); tv != "" {
+		lines = append(lines, "", "TV: "+tv)
+	}
+	return lines
+}
+
+// renderSchedule shows the chosen team's upcoming or recent games.
+func (m *model) renderSchedule() []string {
+	if m.team == nil {
+		return []string{"", "  No team selected. Pick a game, then press a or h for its away or home team."}
+	}
+	if m.loaded != m.scheduleKey() && m.err != nil {
+		return []string{"", fmt.Sprintf("  Couldn't load the %s schedule. Press r to retry.", m.team.FullName())}
+	}
+	if m.loaded != m.scheduleKey() {
+		return []string{"", fmt.Sprintf("  Loading the %s schedule…", m.team.FullName())}
+	}
+	if len(m.schedule) == 0 {
+		return []string{"", fmt.Sprintf("  No %s games found for the %s.", m.mode, m.team.FullName())}
+	}
+
+	title := m.team.FullName() + " — Upcoming Games"
+	if m.mode == "recent" {
+		title = m.team.FullName() + " — Recent Games"
+	}
+	return splitLines(util.FormatTeamSchedule(m.schedule, m.team.Tricode, title))
+}
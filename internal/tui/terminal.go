@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Escape sequences understood by xterm and anything that emulates it.
+const (
+	enterAltScreen = "\033[?1049h"
+	leaveAltScreen = "\033[?1049l"
+	hideCursor     = "\033[?25l"
+	showCursor     = "\033[?25h"
+	cursorHome     = "\033[H"
+	clearScreen    = "\033[2J"
+	clearLine      = "\033[K"
+	clearBelow     = "\033[J"
+)
+
+// terminal is the controlling terminal in raw mode on the alternate screen.
+type terminal struct {
+	in    *os.File
+	out   io.Writer
+	fd    int
+	state *term.State
+}
+
+// openTerminal switches in to raw mode and out to the alternate screen. Call
+// close to put the terminal back the way it was.
+func openTerminal(in, out *os.File) (*terminal, error) {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(out.Fd())) {
+		return nil, fmt.Errorf("bball tui needs an interactive terminal")
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up terminal: %w", err)
+	}
+
+	fmt.Fprint(out, enterAltScreen+hideCursor+clearScreen)
+	return &terminal{in: in, out: out, fd: int(out.Fd()), state: state}, nil
+}
+
+// size returns the terminal's width and height, with a classic 80x24
+// fallback when it can't be read.
+func (t *terminal) size() (width, height int) {
+	width, height, err := term.GetSize(t.fd)
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// draw replaces the screen with lines, clearing whatever the previous frame
+// left behind.
+func (t *terminal) draw(lines []string) {
+	var buf strings.Builder
+	buf.WriteString(cursorHome)
+	for i, line := range lines {
+		if i > 0 {
+			// Raw mode turns off newline translation.
+			buf.WriteString("\r\n")
+		}
+		buf.WriteString(line + clearLine)
+	}
+	buf.WriteString(clearBelow)
+	io.WriteString(t.out, buf.String())
+}
+
+func (t *terminal) close() {
+	fmt.Fprint(t.out, showCursor+leaveAltScreen)
+	_ = term.Restore(int(t.in.Fd()), t.state)
+}
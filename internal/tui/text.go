@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	reverse    = "\033[7m"
+	noReverse  = "\033[27m"
+	resetStyle = "\033[0m"
+)
+
+// wideRanges lists the code points a terminal draws two cells wide: CJK
+// and the emoji the formatters in internal/util use.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115f},
+	{0x231a, 0x231b},
+	{0x23e9, 0x23ec},
+	{0x23f0, 0x23f0},
+	{0x23f3, 0x23f3},
+	{0x2e80, 0xa4cf},
+	{0xac00, 0xd7a3},
+	{0xf900, 0xfaff},
+	{0xff00, 0xff60},
+	{0xffe0, 0xffe6},
+	{0x1f300, 0x1faff},
+}
+
+// runeWidth returns how many terminal cells r takes up.
+func runeWidth(r rune) int {
+	if r < 0x20 || r == 0x200d || (r >= 0xfe00 && r <= 0xfe0f) {
+		return 0
+	}
+	for _, rng := range wideRanges {
+		if r >= rng[0] && r <= rng[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+// escapeLen returns the length of the ANSI escape sequence at the start of
+// s, or 0 if there isn't one.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != 0x1b || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// displayWidth returns how many cells s takes up, ignoring color codes.
+func displayWidth(s string) int {
+	width := 0
+	for len(s) > 0 {
+		if n := escapeLen(s); n > 0 {
+			s = s[n:]
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s)
+		width += runeWidth(r)
+		s = s[n:]
+	}
+	return width
+}
+
+// truncate cuts s to at most width cells, keeping color codes intact and
+// resetting them if anything was cut.
+func truncate(s string, width int) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+
+	var b strings.Builder
+	used, styled := 0, false
+	for len(s) > 0 {
+		if n := escapeLen(s); n > 0 {
+			b.WriteString(s[:n])
+			s, styled = s[n:], true
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s)
+		if used+runeWidth(r) > width {
+			break
+		}
+		b.WriteRune(r)
+		used += runeWidth(r)
+		s = s[n:]
+	}
+	if styled {
+		b.WriteString(resetStyle)
+	}
+	return b.String()
+}
+
+// fit truncates or pads s with spaces to exactly width cells.
+func fit(s string, width int) string {
+	s = truncate(s, width)
+	if pad := width - displayWidth(s); pad > 0 {
+		s += strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// splitLines splits formatter output into lines, dropping the trailing
+// newline.
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimRight(s, "\n"), "\n")
+}
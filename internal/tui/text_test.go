@@ -0,0 +1,37 @@
+package tui
+
+import "testing"
+
+func TestDisplayWidth(t *testing.T) {
+	cases := map[string]int{
+		"BOS 102":                7,
+		"\x1b[32mLIVE\x1b[0m":    4,
+		"🔴 LIVE":                 7,
+		"←↑↓→ move":              9,
+		"\x1b[7m Games \x1b[27m": 7,
+	}
+	for in, want := range cases {
+		if got := displayWidth(in); got != want {
+			t.Errorf("displayWidth(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestTruncateKeepsColorsBalanced(t *testing.T) {
+	got := truncate("\x1b[32mLIVE - Q3\x1b[0m", 4)
+	if want := "\x1b[32mLIVE" + resetStyle; got != want {
+		t.Fatalf("truncate = %q, want %q", got, want)
+	}
+	if got := truncate("🔴 LIVE", 1); got != "" {
+		t.Fatalf("a wide rune that doesn't fit should be dropped, got %q", got)
+	}
+}
+
+func TestFit(t *testing.T) {
+	if got := fit("BOS", 6); got != "BOS   " {
+		t.Fatalf("fit pads to width, got %q", got)
+	}
+	if got := fit("Boston Celtics", 6); got != "Boston" {
+		t.Fatalf("fit cuts to width, got %q", got)
+	}
+}
@@ -0,0 +1,82 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+const boxscoreHeader = "%-20s %3s %7s %7s %7s %4s %4s %4s %4s %4s %4s %4s %4s %4s %4s"
+
+// FormatBoxscore renders both teams' box score tables, away team first
+func FormatBoxscore(box nba.Boxscore) string {
+	builder := strings.Builder{}
+	bold := color.New(color.Bold).SprintFunc()
+
+	g := box.Game
+	builder.WriteString(fmt.Sprintf("\n🏀 %s %d @ %s %d — %s\n",
+		g.AwayTeam.Tricode, g.AwayTeam.Score,
+		g.HomeTeam.Tricode, g.HomeTeam.Score,
+		g.GameStatusText,
+	))
+	builder.WriteString(strings.Repeat("─", 100) + "\n")
+
+	for _, team := range []nba.BoxscoreTeam{g.AwayTeam, g.HomeTeam} {
+		builder.WriteString(fmt.Sprintf("\n%s\n", bold(fmt.Sprintf("%s %s (%s)", team.City, team.Name, team.Tricode))))
+		builder.WriteString(fmt.Sprintf(boxscoreHeader+"\n",
+			"PLAYER", "MIN", "FG", "3P", "FT", "OREB", "DREB", "REB", "AST", "STL", "BLK", "TOV", "PF", "+/-", "PTS"))
+
+		writeBoxscoreSection(&builder, "Starters", team.Starters())
+		writeBoxscoreSection(&builder, "Bench", team.Bench())
+
+		builder.WriteString(formatBoxscoreLine("TOTALS", team.Statistics, false) + "\n")
+
+		if dnp := team.Inactive(); len(dnp) > 0 {
+			builder.WriteString(color.New(color.Faint).Sprint("DNP") + "\n")
+			for _, p := range dnp {
+				builder.WriteString(fmt.Sprintf("  %s — %s\n", p.DisplayName(), p.DNPReason()))
+			}
+		}
+	}
+
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+func writeBoxscoreSection(builder *strings.Builder, title string, players []nba.BoxscorePlayer) {
+	if len(players) == 0 {
+		return
+	}
+	builder.WriteString(color.New(color.Faint).Sprint(title) + "\n")
+	for _, p := range players {
+		name := p.DisplayName()
+		if p.Position != "" {
+			name = fmt.Sprintf("%s %s", name, p.Position)
+		}
+		builder.WriteString(formatBoxscoreLine(name, p.Statistics, true) + "\n")
+	}
+}
+
+// formatBoxscoreLine renders one row; minutes and +/- are left blank for
+// team totals
+func formatBoxscoreLine(name string, s nba.BoxscoreStats, isPlayer bool) string {
+	var minutes, plusMinus string
+	if isPlayer {
+		minutes = fmt.Sprintf("%d", s.MinutesPlayed())
+		plusMinus = fmt.Sprintf("%+d", int(s.PlusMinusPoints))
+	}
+	if runes := []rune(name); len(runes) > 20 {
+		name = string(runes[:20])
+	}
+	return fmt.Sprintf("%-20s %3s %7s %7s %7s %4d %4d %4d %4d %4d %4d %4d %4d %4s %4d",
+		name, minutes,
+		fmt.Sprintf("%d-%d", s.FieldGoalsMade, s.FieldGoalsAttempted),
+		fmt.Sprintf("%d-%d", s.ThreePointersMade, s.ThreePointersAttempted),
+		fmt.Sprintf("%d-%d", s.FreeThrowsMade, s.FreeThrowsAttempted),
+		s.ReboundsOffensive, s.ReboundsDefensive, s.ReboundsTotal,
+		s.Assists, s.Steals, s.Blocks, s.Turnovers, s.FoulsPersonal,
+		plusMinus, s.Points,
+	)
+}
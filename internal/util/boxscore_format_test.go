@@ -0,0 +1,44 @@
+package util
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestFormatBoxscore_SectionsAndDNP(t *testing.T) {
+	box := nba.Boxscore{}
+	box.Game.GameStatusText = "Final"
+	box.Game.HomeTeam = nba.BoxscoreTeam{
+		Team: nba.Team{Name: "Celtics", City: "Boston", Tricode: "BOS", Score: 112},
+		Players: []nba.BoxscorePlayer{
+			{NameI: "J. Tatum", Position: "SF", Starter: "1", Played: "1", Statistics: nba.BoxscoreStats{Minutes: "PT36M12.00S", Points: 31, FieldGoalsMade: 11, FieldGoalsAttempted: 22, PlusMinusPoints: 9}},
+			{NameI: "P. Pritchard", Starter: "0", Played: "1", Statistics: nba.BoxscoreStats{Minutes: "PT18M00.00S", Points: 8}},
+			{NameI: "N. Queta", Starter: "0", Played: "0", NotPlayingReason: "DNP_COACH"},
+		},
+		Statistics: nba.BoxscoreStats{Points: 112},
+	}
+	box.Game.AwayTeam = nba.BoxscoreTeam{Team: nba.Team{Name: "Knicks", City: "New York", Tricode: "NYK", Score: 104}}
+
+	out := FormatBoxscore(box)
+	for _, want := range []string{"NYK 104 @ BOS 112", "Starters", "Bench", "TOTALS", "11-22", "+9", "N. Queta — Coach's Decision"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in boxscore output: %s", want, out)
+		}
+	}
+	if !strings.Contains(out, "J. Tatum SF           36") {
+		t.Fatalf("expected minutes column for starter: %s", out)
+	}
+}
+
+func TestFormatBoxscoreLine_TruncatesByRune(t *testing.T) {
+	line := formatBoxscoreLine("N. Jokić-Šarić-Dončić Jr.", nba.BoxscoreStats{}, true)
+	if !utf8.ValidString(line) {
+		t.Fatalf("expected valid UTF-8, got %q", line)
+	}
+	if !strings.HasPrefix(line, "N. Jokić-Šarić-Donči   0") {
+		t.Fatalf("expected the name cut to 20 characters: %q", line)
+	}
+}
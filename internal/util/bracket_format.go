@@ -0,0 +1,209 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+// A conference bracket is drawn as a tree of 15 rows: the eight first round
+// teams on the even rows, and each later round's team halfway between the
+// two it came from.
+const (
+	bracketRows  = 15
+	bracketWidth = 9  // width of "10 BOS 4"
+	bracketLevel = 14 // columns from one round to the next
+)
+
+var bracketRounds = []string{"First Round", "Semifinals", "Conf Finals", "Finals"}
+
+// FormatBracket renders the playoff bracket from the schedule's series: one
+// tree per conference with each team's wins in every round, then the Finals
+func FormatBracket(series []nba.Series) string {
+	builder := strings.Builder{}
+	bold := color.New(color.Bold).SprintFunc()
+	faint := color.New(color.Faint).SprintFunc()
+
+	builder.WriteString(fmt.Sprintf("\n🏆 %s\n", bold("NBA Playoffs")))
+	builder.WriteString(strings.Repeat("─", 60) + "\n")
+	if len(series) == 0 {
+		builder.WriteString("\nNo playoff games on the schedule yet.\n\n")
+		return builder.String()
+	}
+
+	var finals *nba.Series
+	for i := range series {
+		if series[i].Round == 4 {
+			finals = &series[i]
+		}
+	}
+
+	for _, conference := range []string{"East", "West"} {
+		var header strings.Builder
+		for _, name := range bracketRounds {
+			header.WriteString(fmt.Sprintf("%-*s", bracketLevel, name))
+		}
+		builder.WriteString(fmt.Sprintf("\n%s\n", bold(conference)))
+		builder.WriteString(faint(strings.TrimRight(header.String(), " ")) + "\n")
+		for _, line := range conferenceBracket(series, conference, finals) {
+			builder.WriteString(line + "\n")
+		}
+	}
+
+	builder.WriteString("\n" + bold("NBA Finals") + "\n")
+	if finals == nil {
+		builder.WriteString("  TBD\n\n")
+		return builder.String()
+	}
+	builder.WriteString(fmt.Sprintf("  %s %d - %d %s", finals.Top.Tricode, finals.Top.Wins, finals.Bottom.Wins, finals.Bottom.Tricode))
+	if status := finals.Status(); status != "" {
+		builder.WriteString("  " + status)
+	}
+	builder.WriteString("\n")
+	if finals.Winner != "" {
+		builder.WriteString(fmt.Sprintf("  🏆 Champion: %s\n", bold(finals.Winner)))
+	}
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// conferenceBracket draws one conference's tree and returns its lines.
+func conferenceBracket(series []nba.Series, conference string, finals *nba.Series) []string {
+	// rounds[r] holds round r+1's series in bracket order, nil where one
+	// isn't on the schedule yet.
+	rounds := make([][]*nba.Series, 3)
+	rounds[2] = []*nba.Series{nil}
+	for i := range series {
+		if series[i].Round == 3 && series[i].Conference == conference {
+			rounds[2][0] = &series[i]
+		}
+	}
+	for r := 1; r >= 0; r-- {
+		rounds[r] = arrangeRound(series, r+1, conference, rounds[r+1])
+	}
+
+	grid := make([][]rune, bracketRows)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", 3*bracketLevel+bracketWidth))
+	}
+	put := func(row, col int, s string) {
+		for i, r := range []rune(s) {
+			grid[row][col+i] = r
+		}
+	}
+
+	rows := []int{0, 2, 4, 6, 8, 10, 12, 14}
+	for i, s := range rounds[0] {
+		if s != nil {
+			put(rows[2*i], 0, bracketNode(s.Top.Seed, s.Top.Tricode, strconv.Itoa(s.Top.Wins)))
+			put(rows[2*i+1], 0, bracketNode(s.Bottom.Seed, s.Bottom.Tricode, strconv.Itoa(s.Bottom.Wins)))
+		} else {
+			put(rows[2*i], 0, bracketNode(0, "TBD", ""))
+			put(rows[2*i+1], 0, bracketNode(0, "TBD", ""))
+		}
+	}
+
+	for level := 1; level <= 3; level++ {
+		next := make([]int, len(rows)/2)
+		col := (level - 1) * bracketLevel
+		for i := range next {
+			top, bottom := rows[2*i], rows[2*i+1]
+			next[i] = (top + bottom) / 2
+
+			// Join the pair with ─┐ │ ├─ │ ─┘.
+			put(top, col+bracketWidth, "──┐")
+			put(bottom, col+bracketWidth, "──┘")
+			for row := top + 1; row < bottom; row++ {
+				put(row, col+bracketWidth+2, "│")
+			}
+			put(next[i], col+bracketWidth+2, "├─")
+
+			// The team that came through, and its wins in the round after.
+			var parent *nba.Series
+			if level < 3 {
+				parent = rounds[level][i/2]
+			} else {
+				parent = finals
+			}
+			put(next[i], level*bracketLevel, advancingNode(rounds[level-1][i], parent))
+		}
+		rows = next
+	}
+
+	lines := make([]string, len(grid))
+	for i, row := range grid {
+		lines[i] = strings.TrimRight(string(row), " ")
+	}
+	return lines
+}
+
+// arrangeRound puts a conference's series in round in bracket order: under
+// each series of the next round, the two that fed it, and the rest, in
+// series number order, in the slots still open.
+func arrangeRound(series []nba.Series, round int, conference string, next []*nba.Series) []*nba.Series {
+	var pool []*nba.Series
+	for i := range series {
+		if series[i].Round == round && series[i].Conference == conference {
+			pool = append(pool, &series[i])
+		}
+	}
+	take := func(tricode string) *nba.Series {
+		for i, s := range pool {
+			if s != nil && s.Has(tricode) {
+				pool[i] = nil
+				return s
+			}
+		}
+		return nil
+	}
+
+	slots := make([]*nba.Series, 2*len(next))
+	for i, parent := range next {
+		if parent != nil {
+			slots[2*i] = take(parent.Top.Tricode)
+			slots[2*i+1] = take(parent.Bottom.Tricode)
+		}
+	}
+	for i := range slots {
+		for j := 0; slots[i] == nil && j < len(pool); j++ {
+			if pool[j] != nil {
+				slots[i], pool[j] = pool[j], nil
+			}
+		}
+	}
+	return slots
+}
+
+// advancingNode returns the winner of s with its wins in parent, or "TBD"
+// while s is undecided.
+func advancingNode(s, parent *nba.Series) string {
+	if s == nil || s.Winner == "" {
+		return bracketNode(0, "TBD", "")
+	}
+	seed := s.Top.Seed
+	if s.Winner == s.Bottom.Tricode {
+		seed = s.Bottom.Seed
+	}
+	wins := ""
+	if parent != nil {
+		for _, t := range []nba.SeriesTeam{parent.Top, parent.Bottom} {
+			if t.Tricode == s.Winner {
+				wins = strconv.Itoa(t.Wins)
+			}
+		}
+	}
+	return bracketNode(seed, s.Winner, wins)
+}
+
+// bracketNode returns a team's label padded to the node width, like
+// " 1 BOS 4"
+func bracketNode(seed int, tricode, wins string) string {
+	s := "  "
+	if seed > 0 {
+		s = fmt.Sprintf("%2d", seed)
+	}
+	return fmt.Sprintf("%-*s", bracketWidth, strings.TrimRight(fmt.Sprintf("%s %-3s %s", s, tricode, wins), " "))
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestFormatBracket(t *testing.T) {
+	team := func(tricode string, seed, wins int) nba.SeriesTeam {
+		return nba.SeriesTeam{Tricode: tricode, Seed: seed, Wins: wins}
+	}
+	series := []nba.Series{
+		{Round: 1, Number: 0, Conference: "East", Top: team("CLE", 1, 4), Bottom: team("MIA", 8, 0), Winner: "CLE"},
+		{Round: 1, Number: 1, Conference: "East", Top: team("IND", 4, 4), Bottom: team("MIL", 5, 1), Winner: "IND"},
+		{Round: 1, Number: 2, Conference: "East", Top: team("NYK", 3, 4), Bottom: team("DET", 6, 2), Winner: "NYK"},
+		{Round: 1, Number: 3, Conference: "East", Top: team("BOS", 2, 3), Bottom: team("ORL", 7, 1)},
+		// The second round lists IND-CLE first; the bracket still follows
+		// the teams.
+		{Round: 2, Number: 0, Conference: "East", Top: team("CLE", 1, 1), Bottom: team("IND", 4, 3)},
+		{Round: 1, Number: 4, Conference: "West", Top: team("OKC", 1, 2), Bottom: team("MEM", 8, 0)},
+	}
+
+	out := FormatBracket(series)
+	lines := strings.Split(out, "\n")
+	want := []string{
+		" 1 CLE 4 ──┐",
+		"           ├─  1 CLE 1 ──┐",
+		" 8 MIA 0 ──┘             │",
+		"                         ├─    TBD   ──┐",
+		"           ├─  4 IND 3 ──┘             │",
+		"           ├─    TBD   ──┘",
+		" 7 ORL 1 ──┘",
+	}
+	for _, w := range want {
+		found := false
+		for _, line := range lines {
+			if line == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing line %q in:\n%s", w, out)
+		}
+	}
+	if !strings.Contains(out, " 1 OKC 2") || !strings.Contains(out, "NBA Finals\n  TBD") {
+		t.Errorf("expected the West's first round and no Finals yet:\n%s", out)
+	}
+
+	if out := FormatBracket(nil); !strings.Contains(out, "No playoff games") {
+		t.Errorf("expected an empty bracket note:\n%s", out)
+	}
+}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/internetdrew/bball/internal/cache"
+)
+
+// FormatCacheEntries lists cached responses with their size, age and validators
+func FormatCacheEntries(dir string, entries []cache.Entry) string {
+	builder := strings.Builder{}
+	bold := color.New(color.Bold).SprintFunc()
+	faint := color.New(color.Faint).SprintFunc()
+
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+
+	builder.WriteString(fmt.Sprintf("\n🗄  %s - %d response(s), %s\n", bold("Cache"), len(entries), FormatBytes(total)))
+	builder.WriteString(faint(dir) + "\n")
+	builder.WriteString(strings.Repeat("─", 60) + "\n\n")
+
+	for _, e := range entries {
+		builder.WriteString(fmt.Sprintf("%s\n", e.URL))
+		builder.WriteString(fmt.Sprintf("  %s, fetched %s", FormatBytes(e.Size), FormatAge(e.Age())))
+		if e.ETag != "" {
+			builder.WriteString(fmt.Sprintf(", etag %s", e.ETag))
+		}
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// FormatBytes returns a human-readable size like "3.2 MB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatAge returns a relative age like "12s ago" or "3h ago"
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return "just now"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/internetdrew/bball/internal/cache"
+)
+
+func TestFormatAge(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                "just now",
+		12 * time.Second: "12s ago",
+		5 * time.Minute:  "5m ago",
+		3 * time.Hour:    "3h ago",
+		72 * time.Hour:   "3d ago",
+	}
+	for d, want := range cases {
+		if got := FormatAge(d); got != want {
+			t.Errorf("FormatAge(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	if got := FormatBytes(512); got != "512 B" {
+		t.Errorf("unexpected: %s", got)
+	}
+	if got := FormatBytes(3 * 1024 * 1024); got != "3.0 MB" {
+		t.Errorf("unexpected: %s", got)
+	}
+}
+
+func TestFormatCacheEntries_ListsURLs(t *testing.T) {
+	entries := []cache.Entry{{URL: "https://cdn.nba.com/schedule.json", Size: 2048, ETag: `"abc"`, FetchedAt: time.Now()}}
+	out := FormatCacheEntries("/tmp/bball", entries)
+	if !strings.Contains(out, "https://cdn.nba.com/schedule.json") || !strings.Contains(out, `"abc"`) {
+		t.Fatalf("expected entry details in output: %s", out)
+	}
+}
@@ -9,39 +9,33 @@ import (
 	"github.com/internetdrew/bball/internal/nba"
 )
 
-// FormatGameDate converts ISO 8601 timestamp to human-readable format
+// FormatGameDate converts ISO 8601 timestamp to human-readable format in Location
 func FormatGameDate(dateStr string) string {
+	return formatGameDateIn(dateStr, Location)
+}
+
+func formatGameDateIn(dateStr string, loc *time.Location) string {
 	t, err := time.Parse(time.RFC3339, dateStr)
 	if err != nil {
 		return dateStr // fallback to original if parsing fails
 	}
 
-	// Convert to EST/EDT
-	loc, err := time.LoadLocation("America/New_York")
-	if err != nil {
-		loc = time.UTC
-	}
-	t = t.In(loc)
-
 	// Format as "Monday, January 2, 2006 at 3:04 PM EST"
-	return t.Format("Monday, January 2, 2006 at 3:04 PM MST")
+	return t.In(loc).Format("Monday, January 2, 2006 at 3:04 PM MST")
 }
 
-// FormatGameDay returns a concise local date like "Mon Jan 2"
+// FormatGameDay returns a concise date in Location like "Mon Jan 2"
 func FormatGameDay(dateStr string) string {
+	return formatGameDayIn(dateStr, Location)
+}
+
+func formatGameDayIn(dateStr string, loc *time.Location) string {
 	t, err := time.Parse(time.RFC3339, dateStr)
 	if err != nil {
 		return "" // keep it silent; caller can omit if empty
 	}
 
-	// Convert to EST/EDT
-	loc, err := time.LoadLocation("America/New_York")
-	if err != nil {
-		loc = time.UTC
-	}
-	t = t.In(loc)
-
-	return t.Format("Mon Jan 2")
+	return t.In(loc).Format("Mon Jan 2")
 }
 
 // FormatScore returns a concise string like "BKN 109 - MIN 125"
@@ -69,50 +63,91 @@ func FormatGameSummary(summary nba.GameSummary) string {
 		summary.Game.AwayTeam.Name,
 		summary.Game.GameStatusText,
 	))
-	builder.WriteString(fmt.Sprintf("📅 %s\n\n", FormatGameDate(summary.Game.GameTimeUTC)))
+	builder.WriteString(fmt.Sprintf("📅 %s\n", formatGameDateIn(summary.Game.StartTimeUTC(), gameLocation(summary.Game))))
+	if series := summary.Game.SeriesLabel(); series != "" {
+		builder.WriteString(fmt.Sprintf("🏆 %s\n", series))
+	}
+	builder.WriteString("\n")
 	builder.WriteString(FormatScore(summary.Game) + "\n\n")
+	if linescore := FormatLinescore(summary.Game); linescore != "" {
+		builder.WriteString(linescore + "\n")
+	}
 	builder.WriteString("Top Performers:\n")
 	builder.WriteString(FormatTopPerformers(summary.TopPerformers) + "\n\n")
-	builder.WriteString(fmt.Sprintf("Last updated: %s\n", summary.LastUpdated))
+	builder.WriteString(fmt.Sprintf("Last updated: %s\n", formatLastUpdated(summary)))
+	if summary.Stale {
+		builder.WriteString(color.YellowString("⚠️ The scoreboard hasn't updated in %s; the score may be behind.",
+			strings.TrimSuffix(formatLastUpdated(summary), " ago")) + "\n")
+	}
 	return builder.String()
 }
 
+// formatLastUpdated returns the age of the summary's feed like "12s ago",
+// or LastUpdated as is when it isn't a timestamp
+func formatLastUpdated(summary nba.GameSummary) string {
+	if _, err := time.Parse(time.RFC3339, summary.LastUpdated); err != nil {
+		return summary.LastUpdated
+	}
+	return FormatAge(time.Duration(summary.Age) * time.Second)
+}
+
 func FormatGamesList(games []nba.Game) string {
-	builder := strings.Builder{}
+	return FormatGamesListChanges(games, nil)
+}
 
-	// Colors
-	green := color.New(color.FgGreen).SprintFunc()
-	yellow := color.New(color.FgYellow).SprintFunc()
-	cyan := color.New(color.FgCyan).SprintFunc()
+// FormatGamesListChanges is FormatGamesList with a highlighted line under
+// each game that changed since the last refresh. changes is keyed by game ID.
+func FormatGamesListChanges(games []nba.Game, changes map[string]nba.GameChanges) string {
+	return formatGamesList(games, changes, false)
+}
+
+// FormatGamesListDetail is FormatGamesListChanges with the linescore under
+// each live or final game
+func FormatGamesListDetail(games []nba.Game, changes map[string]nba.GameChanges) string {
+	return formatGamesList(games, changes, true)
+}
+
+func formatGamesList(games []nba.Game, changes map[string]nba.GameChanges, detail bool) string {
+	builder := strings.Builder{}
 
 	builder.WriteString(fmt.Sprintf("\n🏀 NBA Games - %d game(s)\n", len(games)))
 	builder.WriteString(strings.Repeat("─", 60) + "\n\n")
 
 	for i, game := range games {
 		// Status indicator
-		var status string
-		switch game.GameStatus {
-		case 1: // Scheduled
-			status = cyan("⏰ Scheduled")
-		case 2: // Live
-			status = green(fmt.Sprintf("🔴 LIVE - Q%d %s", game.Period, game.GameClock))
-		case 3: // Final
-			status = yellow("✓ Final")
-		default:
-			status = game.GameStatusText
-		}
+		status := ColorStatus(game, statusIcon(game)+GameStatusLabel(game))
 
-		builder.WriteString(fmt.Sprintf("%s - %s\n", status, FormatGameDate(game.GameTimeUTC)))
+		builder.WriteString(fmt.Sprintf("%s - %s\n", status, formatGameDateIn(game.StartTimeUTC(), gameLocation(game))))
 		builder.WriteString(fmt.Sprintf("  %s %s vs %s %s\n",
 			game.AwayTeam.Tricode,
-			formatScore(game.AwayTeam.Score, game.GameStatus),
+			formatScore(game.AwayTeam.Score, game.State()),
 			game.HomeTeam.Tricode,
-			formatScore(game.HomeTeam.Score, game.GameStatus),
+			formatScore(game.HomeTeam.Score, game.State()),
 		))
 
-		// Show time for scheduled games
-		if game.GameStatus == 1 {
-			builder.WriteString(fmt.Sprintf("  %s\n", game.GameStatusText))
+		if series := game.SeriesLabel(); series != "" {
+			builder.WriteString(fmt.Sprintf("  🏆 %s\n", series))
+		}
+
+		if c, ok := changes[game.ID]; ok && c.Any() {
+			builder.WriteString(fmt.Sprintf("  %s\n", FormatChanges(game, c)))
+		}
+
+		if state := game.State(); detail && (state.IsLive() || state.IsFinal()) {
+			if linescore := FormatLinescore(game); linescore != "" {
+				builder.WriteString("\n")
+				for _, line := range strings.Split(strings.TrimSuffix(linescore, "\n"), "\n") {
+					builder.WriteString("    " + line + "\n")
+				}
+			}
+		}
+
+		// Show time and broadcasters for scheduled games
+		if game.State().IsUpcoming() {
+			builder.WriteString(fmt.Sprintf("  %s\n", FormatTipTime(game)))
+			if tv := FormatBroadcasters(game.Broadcasters); tv != "" {
+				builder.WriteString(fmt.Sprintf("  📺 %s\n", tv))
+			}
 		}
 
 		// Add separator between games (but not after the last one)
@@ -125,9 +160,107 @@ func FormatGamesList(games []nba.Game) string {
 	return builder.String()
 }
 
+// GameStatusLabel returns a game's status without decoration, like
+// "Scheduled", "LIVE - Q3 5:21", "Final" or "Postponed"
+func GameStatusLabel(game nba.Game) string {
+	state := game.State()
+	if state.IsLive() {
+		return "LIVE - " + game.Clock().String()
+	}
+	return state.String()
+}
+
+// ColorStatus colors s the way game's status is shown in a games list:
+// cyan when scheduled, green when live, yellow when final and red when
+// postponed, cancelled or suspended
+func ColorStatus(game nba.Game, s string) string {
+	switch state := game.State(); {
+	case state.IsUpcoming():
+		return color.New(color.FgCyan).Sprint(s)
+	case state.IsLive():
+		return color.New(color.FgGreen).Sprint(s)
+	case state.IsFinal():
+		return color.New(color.FgYellow).Sprint(s)
+	case state.IsOff():
+		return color.New(color.FgRed).Sprint(s)
+	default:
+		return s
+	}
+}
+
+func statusIcon(game nba.Game) string {
+	switch state := game.State(); {
+	case state.IsUpcoming():
+		return "⏰ "
+	case state.IsLive():
+		return "🔴 "
+	case state.IsFinal():
+		return "✓ "
+	case state.IsOff():
+		return "⚠️ "
+	default:
+		return ""
+	}
+}
+
+// FormatChanges summarizes what changed in a game since the last refresh,
+// e.g. "↳ BOS +3 · Q4 underway · new BOS leader: J. Tatum"
+func FormatChanges(game nba.Game, c nba.GameChanges) string {
+	magenta := color.New(color.FgMagenta, color.Bold).SprintFunc()
+
+	var parts []string
+	if c.AwayDelta > 0 {
+		parts = append(parts, fmt.Sprintf("%s +%d", game.AwayTeam.Tricode, c.AwayDelta))
+	}
+	if c.HomeDelta > 0 {
+		parts = append(parts, fmt.Sprintf("%s +%d", game.HomeTeam.Tricode, c.HomeDelta))
+	}
+	if c.LeadChanged {
+		parts = append(parts, "lead change")
+	}
+	if c.StatusChanged && game.State().IsFinal() {
+		parts = append(parts, "final")
+	} else if c.StatusChanged && game.State().IsLive() {
+		parts = append(parts, "tip-off")
+	} else if c.PeriodChanged {
+		parts = append(parts, fmt.Sprintf("%s underway", nba.PeriodLabel(game.Period)))
+	}
+	if c.NewAwayLeader {
+		parts = append(parts, fmt.Sprintf("new %s leader: %s", game.AwayTeam.Tricode, game.GameLeaders.AwayLeaders.Name))
+	}
+	if c.NewHomeLeader {
+		parts = append(parts, fmt.Sprintf("new %s leader: %s", game.HomeTeam.Tricode, game.GameLeaders.HomeLeaders.Name))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return magenta("↳ " + strings.Join(parts, " · "))
+}
+
+// FormatBroadcasters returns national broadcasters, or the local home and
+// away TV broadcasters when no national one is listed, e.g. "ESPN" or "MSG / NBCSB"
+func FormatBroadcasters(b nba.Broadcasters) string {
+	names := broadcasterNames(b.National)
+	if len(names) == 0 {
+		names = append(broadcasterNames(b.HomeTV), broadcasterNames(b.AwayTV)...)
+	}
+	return strings.Join(names, " / ")
+}
+
+func broadcasterNames(list []nba.Broadcaster) []string {
+	names := make([]string, 0, len(list))
+	for _, b := range list {
+		if b.Display != "" {
+			names = append(names, b.Display)
+		}
+	}
+	return names
+}
+
 // formatScore returns the score as a string, or empty if game hasn't started
-func formatScore(score int, gameStatus int) string {
-	if gameStatus == 1 { // Scheduled
+func formatScore(score int, state nba.GameState) string {
+	if !state.HasScore() {
 		return ""
 	}
 	return fmt.Sprintf("%d", score)
@@ -143,13 +276,14 @@ func FormatTeamSchedule(games []nba.Game, teamQuery string, title string) string
 	cyan := color.New(color.FgCyan).SprintFunc()
 	bold := color.New(color.Bold).SprintFunc()
 
+	franchise, _ := nba.Resolve(teamQuery)
+
 	builder.WriteString(fmt.Sprintf("\n📅 %s - %d game(s)\n", bold(title), len(games)))
 	builder.WriteString(strings.Repeat("─", 60) + "\n\n")
 
 	for i, game := range games {
 		// Determine if team is home or away
-		isHome := strings.Contains(strings.ToLower(game.HomeTeam.Name), teamQuery) ||
-			strings.EqualFold(game.HomeTeam.Tricode, teamQuery)
+		isHome := isHomeTeam(game, franchise, teamQuery)
 
 		var opponent string
 		var location string
@@ -163,19 +297,19 @@ func FormatTeamSchedule(games []nba.Game, teamQuery string, title string) string
 
 		// Status and score
 		var statusLine string
-		switch game.GameStatus {
-		case 1: // Scheduled
-			dateSrc := game.GameDateTimeUTC
-			if dateSrc == "" {
-				dateSrc = game.GameTimeUTC
-			}
-			day := FormatGameDay(dateSrc)
+		day := formatGameDayIn(game.StartTimeUTC(), gameLocation(game))
+		switch state := game.State(); state {
+		case nba.Scheduled:
 			if day != "" {
-				statusLine = cyan(fmt.Sprintf("%s — %s %s %s", day, location, opponent, game.GameStatusText))
+				statusLine = cyan(fmt.Sprintf("%s — %s %s %s", day, location, opponent, FormatTipTime(game)))
 			} else {
 				statusLine = cyan(fmt.Sprintf("%s %s %s", location, opponent, game.GameStatusText))
 			}
-		case 2: // Live
+		case nba.TimeTBD:
+			statusLine = cyan(strings.TrimPrefix(fmt.Sprintf("%s — %s %s time TBD", day, location, opponent), " — "))
+		case nba.Postponed, nba.Cancelled, nba.Suspended:
+			statusLine = color.RedString(strings.TrimPrefix(fmt.Sprintf("%s — %s %s ⚠️ %s", day, location, opponent, state), " — "))
+		case nba.Live:
 			var teamScore, oppScore int
 			if isHome {
 				teamScore = game.HomeTeam.Score
@@ -184,9 +318,9 @@ func FormatTeamSchedule(games []nba.Game, teamQuery string, title string) string
 				teamScore = game.AwayTeam.Score
 				oppScore = game.HomeTeam.Score
 			}
-			statusLine = green(fmt.Sprintf("🔴 LIVE %s %s - %d vs %d (Q%d %s)",
-				location, opponent, teamScore, oppScore, game.Period, game.GameClock))
-		case 3: // Final
+			statusLine = green(fmt.Sprintf("🔴 LIVE %s %s - %d vs %d (%s)",
+				location, opponent, teamScore, oppScore, game.Clock()))
+		case nba.Final:
 			var teamScore, oppScore int
 			var result string
 			if isHome {
@@ -218,3 +352,12 @@ func FormatTeamSchedule(games []nba.Game, teamQuery string, title string) string
 	builder.WriteString("\n")
 	return builder.String()
 }
+
+// isHomeTeam reports whether the team the schedule is for is at home in game.
+// It falls back to comparing tricodes if teamQuery is not a known team.
+func isHomeTeam(game nba.Game, franchise *nba.Franchise, teamQuery string) bool {
+	if franchise != nil {
+		return franchise.Matches(game.HomeTeam)
+	}
+	return strings.EqualFold(game.HomeTeam.Tricode, teamQuery)
+}
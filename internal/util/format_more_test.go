@@ -3,6 +3,7 @@ package util
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/internetdrew/bball/internal/nba"
 )
@@ -46,3 +47,94 @@ func TestFormatTeamSchedule_Basic(t *testing.T) {
 		t.Fatalf("expected opponent tricode in schedule: %s", out)
 	}
 }
+
+func TestFormatGamesList_ShowsBroadcastersForScheduled(t *testing.T) {
+	games := []nba.Game{
+		{
+			GameStatus: 1, GameStatusText: "7:30 pm ET", GameDateTimeUTC: "2024-10-22T23:30:00Z",
+			HomeTeam:     nba.Team{Tricode: "BOS"},
+			AwayTeam:     nba.Team{Tricode: "NYK"},
+			Broadcasters: nba.Broadcasters{HomeTV: []nba.Broadcaster{{Display: "NBCSB"}}, AwayTV: []nba.Broadcaster{{Display: "MSG"}}},
+		},
+	}
+	out := FormatGamesList(games)
+	if !strings.Contains(out, "NBCSB / MSG") {
+		t.Fatalf("expected local broadcasters in output: %s", out)
+	}
+	if !strings.Contains(out, "2024") {
+		t.Fatalf("expected schedule date in output: %s", out)
+	}
+}
+
+func TestFormatGamesListChanges_HighlightsChangedGames(t *testing.T) {
+	games := []nba.Game{
+		{ID: "1", GameStatus: 2, Period: 4, HomeTeam: nba.Team{Tricode: "BOS", Score: 80}, AwayTeam: nba.Team{Tricode: "NYK", Score: 78}},
+		{ID: "2", GameStatus: 2, Period: 2, HomeTeam: nba.Team{Tricode: "MIA", Score: 40}, AwayTeam: nba.Team{Tricode: "CHI", Score: 38}},
+	}
+	changes := map[string]nba.GameChanges{"1": {HomeDelta: 3, PeriodChanged: true}}
+
+	out := FormatGamesListChanges(games, changes)
+	if !strings.Contains(out, "BOS +3") || !strings.Contains(out, "Q4 underway") {
+		t.Fatalf("expected change line for game 1: %s", out)
+	}
+	if strings.Count(out, "↳") != 1 {
+		t.Fatalf("expected exactly one change line: %s", out)
+	}
+}
+
+func TestFormatGamesList_ShowsPlayoffSeries(t *testing.T) {
+	game := nba.Game{
+		ID: "0042400305", GameStatus: 3, GameStatusText: "Final",
+		SeriesGameNumber: "Game 5", SeriesText: "BOS leads 3-2",
+		HomeTeam: nba.Team{Tricode: "BOS", Score: 110}, AwayTeam: nba.Team{Tricode: "NYK", Score: 102},
+	}
+	if out := FormatGamesList([]nba.Game{game}); !strings.Contains(out, "Game 5 — BOS leads 3-2") {
+		t.Fatalf("expected the series line in the games list: %s", out)
+	}
+	if out := FormatGameSummary(nba.NewGameSummary(game)); !strings.Contains(out, "Game 5 — BOS leads 3-2") {
+		t.Fatalf("expected the series line in the summary: %s", out)
+	}
+}
+
+func TestFormatGamesList_LiveClock(t *testing.T) {
+	games := []nba.Game{
+		{GameStatus: 2, Period: 5, GameClock: "PT02M05.00S", HomeTeam: nba.Team{Tricode: "BOS"}, AwayTeam: nba.Team{Tricode: "NYK"}},
+		{GameStatus: 2, Period: 2, GameStatusText: "Halftime", GameClock: "PT00M00.00S", HomeTeam: nba.Team{Tricode: "MIA"}, AwayTeam: nba.Team{Tricode: "CHI"}},
+	}
+	out := FormatGamesList(games)
+	if !strings.Contains(out, "LIVE - OT 2:05") || !strings.Contains(out, "LIVE - Halftime") || strings.Contains(out, "PT") {
+		t.Fatalf("expected readable clocks: %s", out)
+	}
+}
+
+func TestFormatGamesList_ShowsPostponed(t *testing.T) {
+	games := []nba.Game{
+		{GameStatus: 1, GameStatusText: "PPD", GameDateTimeUTC: "2025-01-10T00:30:00Z", HomeTeam: nba.Team{Tricode: "LAL"}, AwayTeam: nba.Team{Tricode: "CHA"}},
+	}
+	out := FormatGamesList(games)
+	if !strings.Contains(out, "Postponed") || strings.Contains(out, "⏰") {
+		t.Fatalf("expected the game to be shown as postponed: %s", out)
+	}
+
+	out = FormatTeamSchedule(games, "lal", "Upcoming Games")
+	if !strings.Contains(out, "vs CHA ⚠️ Postponed") {
+		t.Fatalf("expected the postponed game in the schedule: %s", out)
+	}
+}
+
+func TestFormatGameSummary_FeedAge(t *testing.T) {
+	updated := time.Date(2024, 11, 1, 20, 15, 0, 0, time.UTC)
+	summary := nba.NewGameSummary(nba.Game{GameStatus: 2, Period: 3,
+		HomeTeam: nba.Team{Tricode: "BOS"}, AwayTeam: nba.Team{Tricode: "NYK"}})
+
+	summary.SetUpdated(updated, updated.Add(12*time.Second))
+	out := FormatGameSummary(summary)
+	if !strings.Contains(out, "Last updated: 12s ago") || strings.Contains(out, "hasn't updated") {
+		t.Fatalf("expected a fresh feed's age: %s", out)
+	}
+
+	summary.SetUpdated(updated, updated.Add(5*time.Minute))
+	if out := FormatGameSummary(summary); !strings.Contains(out, "The scoreboard hasn't updated in 5m") {
+		t.Fatalf("expected a stale feed warning: %s", out)
+	}
+}
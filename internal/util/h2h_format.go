@@ -0,0 +1,91 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+// FormatHeadToHead renders a season series: one line per meeting with the
+// running series record, then the totals and the next meeting
+func FormatHeadToHead(h nba.HeadToHead) string {
+	builder := strings.Builder{}
+	bold := color.New(color.Bold).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	builder.WriteString(fmt.Sprintf("\n🤝 %s\n", bold(fmt.Sprintf("%s vs %s — season series", h.Team, h.Opponent))))
+	builder.WriteString(strings.Repeat("─", 60) + "\n\n")
+
+	if len(h.Meetings) == 0 {
+		builder.WriteString("No meetings on this season's schedule.\n\n")
+		return builder.String()
+	}
+
+	for _, m := range h.Meetings {
+		g := m.Game
+		where := "@ " + g.HomeTeam.Tricode
+		if m.Home {
+			where = "vs " + g.AwayTeam.Tricode
+		}
+		day := formatGameDayIn(g.StartTimeUTC(), gameLocation(g))
+		if day == "" {
+			day = "TBD"
+		}
+
+		var line string
+		switch state := g.State(); {
+		case state.IsFinal():
+			result := color.RedString("L")
+			if m.Winner == h.Team {
+				result = green("W")
+			}
+			line = fmt.Sprintf("%-10s %-7s %s %s   %s", day, where, result, finalScore(m), seriesStatus(h.Team, h.Opponent, *m.Series))
+		case state.IsLive():
+			line = green(fmt.Sprintf("%-10s %-7s 🔴 LIVE %s", day, where, g.Clock()))
+		case state.IsOff():
+			line = color.RedString(fmt.Sprintf("%-10s %-7s ⚠️ %s", day, where, state))
+		case state.IsTimeTBD():
+			line = cyan(fmt.Sprintf("%-10s %-7s time TBD", day, where))
+		default:
+			line = cyan(fmt.Sprintf("%-10s %-7s %s", day, where, FormatTipTime(g)))
+		}
+		builder.WriteString(line + "\n")
+	}
+
+	builder.WriteString("\n")
+	builder.WriteString(fmt.Sprintf("Series:             %s\n", seriesStatus(h.Team, h.Opponent, h.Record)))
+	builder.WriteString(fmt.Sprintf("Point differential: %s %+d\n", h.Team, h.PointDiff))
+	builder.WriteString(fmt.Sprintf("Home / away:        %s %s at home, %s on the road\n", h.Team, h.Home, h.Away))
+	if h.Next != nil {
+		g := *h.Next
+		builder.WriteString(fmt.Sprintf("Next meeting:       %s — %s @ %s, %s\n",
+			formatGameDayIn(g.StartTimeUTC(), gameLocation(g)), g.AwayTeam.Tricode, g.HomeTeam.Tricode, FormatTipTime(g)))
+	} else {
+		builder.WriteString("Next meeting:       none scheduled\n")
+	}
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// finalScore returns the winner's score first, like "132-109"
+func finalScore(m nba.Meeting) string {
+	high, low := m.Game.HomeTeam.Score, m.Game.AwayTeam.Score
+	if low > high {
+		high, low = low, high
+	}
+	return fmt.Sprintf("%d-%d", high, low)
+}
+
+// seriesStatus describes a series record like "BOS leads 2-1" or "Tied 1-1"
+func seriesStatus(team, opponent string, r nba.Record) string {
+	switch {
+	case r.Wins > r.Losses:
+		return fmt.Sprintf("%s leads %d-%d", team, r.Wins, r.Losses)
+	case r.Losses > r.Wins:
+		return fmt.Sprintf("%s leads %d-%d", opponent, r.Losses, r.Wins)
+	}
+	return fmt.Sprintf("Tied %d-%d", r.Wins, r.Losses)
+}
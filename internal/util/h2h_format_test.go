@@ -0,0 +1,47 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestFormatHeadToHead(t *testing.T) {
+	withTimezone(t, "America/New_York")
+
+	next := nba.Game{GameStatus: 1, GameDateTimeUTC: "2025-04-08T23:30:00Z",
+		HomeTeam: nba.Team{Tricode: "NYK"}, AwayTeam: nba.Team{Tricode: "BOS"}}
+	h := nba.HeadToHead{
+		Team: "NYK", Opponent: "BOS",
+		Meetings: []nba.Meeting{
+			{
+				Game: nba.Game{GameStatus: 3, GameDateTimeUTC: "2024-10-22T23:30:00Z",
+					HomeTeam: nba.Team{Tricode: "BOS", Score: 132}, AwayTeam: nba.Team{Tricode: "NYK", Score: 109}},
+				Winner: "BOS", Margin: -23, Series: &nba.Record{Losses: 1},
+			},
+			{Game: next, Home: true},
+		},
+		Record: nba.Record{Losses: 1}, PointDiff: -23, Away: nba.Record{Losses: 1},
+		Next: &next,
+	}
+
+	out := FormatHeadToHead(h)
+	for _, want := range []string{
+		"Tue Oct 22", "@ BOS", "132-109", "BOS leads 1-0",
+		"Point differential: NYK -23",
+		"NYK 0-0 at home, 0-1 on the road",
+		"Next meeting:       Tue Apr 8 — BOS @ NYK, 7:30 PM EDT",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatHeadToHead_NoMeetings(t *testing.T) {
+	out := FormatHeadToHead(nba.HeadToHead{Team: "NYK", Opponent: "LAL"})
+	if !strings.Contains(out, "No meetings") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+}
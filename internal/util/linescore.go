@@ -0,0 +1,60 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+// FormatLinescore returns a period-by-period score table for a game, or ""
+// when the feed has no period scores:
+//
+//	       Q1  Q2  Q3  Q4    T
+//	NYK    28  25  30  22  105
+//	BOS    31  24  27  26  108
+//
+// While the game is live it adds each team's timeouts left (TOL) and
+// whether it's in the bonus.
+func FormatLinescore(game nba.Game) string {
+	periods := len(game.AwayTeam.Periods)
+	if len(game.HomeTeam.Periods) > periods {
+		periods = len(game.HomeTeam.Periods)
+	}
+	if periods == 0 {
+		return ""
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString("     ")
+	for p := 1; p <= periods; p++ {
+		builder.WriteString(fmt.Sprintf("%4s", nba.PeriodLabel(p)))
+	}
+	live := game.State().IsLive()
+	builder.WriteString(fmt.Sprintf("%5s", "T"))
+	if live {
+		builder.WriteString(fmt.Sprintf("%5s", "TOL"))
+	}
+	builder.WriteString("\n")
+
+	for _, team := range []nba.Team{game.AwayTeam, game.HomeTeam} {
+		builder.WriteString(fmt.Sprintf("%-5s", team.Tricode))
+		for p := 0; p < periods; p++ {
+			if p < len(team.Periods) {
+				builder.WriteString(fmt.Sprintf("%4d", team.Periods[p].Score))
+			} else {
+				builder.WriteString(fmt.Sprintf("%4s", ""))
+			}
+		}
+		builder.WriteString(fmt.Sprintf("%5d", team.Score))
+		if live {
+			builder.WriteString(fmt.Sprintf("%5d", team.TimeoutsRemaining))
+			if team.InBonus {
+				builder.WriteString("  bonus")
+			}
+		}
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,85 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func periods(scores ...int) []nba.Period {
+	out := make([]nba.Period, len(scores))
+	for i, s := range scores {
+		out[i] = nba.Period{Period: i + 1, Score: s}
+	}
+	return out
+}
+
+func TestFormatLinescore(t *testing.T) {
+	game := nba.Game{
+		AwayTeam: nba.Team{Tricode: "NYK", Score: 115, Periods: periods(28, 25, 30, 22, 10)},
+		HomeTeam: nba.Team{Tricode: "BOS", Score: 113, Periods: periods(31, 24, 27, 23, 8)},
+	}
+
+	want := "" +
+		"       Q1  Q2  Q3  Q4  OT    T\n" +
+		"NYK    28  25  30  22  10  115\n" +
+		"BOS    31  24  27  23   8  113\n"
+	if got := FormatLinescore(game); got != want {
+		t.Fatalf("FormatLinescore =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatLinescore_InProgress(t *testing.T) {
+	game := nba.Game{
+		AwayTeam: nba.Team{Tricode: "NYK", Score: 28, Periods: periods(28)},
+		HomeTeam: nba.Team{Tricode: "BOS", Score: 31, Periods: periods(31)},
+	}
+	if out := FormatLinescore(game); !strings.Contains(out, "Q1") || strings.Contains(out, "Q2") {
+		t.Fatalf("unexpected linescore: %s", out)
+	}
+	if out := FormatLinescore(nba.Game{}); out != "" {
+		t.Fatalf("expected no linescore without periods, got %q", out)
+	}
+}
+
+func TestFormatLinescore_LiveShowsTimeoutsAndBonus(t *testing.T) {
+	game := nba.Game{
+		GameStatus: 2, Period: 2,
+		AwayTeam: nba.Team{Tricode: "NYK", Score: 50, Periods: periods(28, 22), TimeoutsRemaining: 4},
+		HomeTeam: nba.Team{Tricode: "BOS", Score: 52, Periods: periods(31, 21), TimeoutsRemaining: 2, InBonus: true},
+	}
+	want := "" +
+		"       Q1  Q2    T  TOL\n" +
+		"NYK    28  22   50    4\n" +
+		"BOS    31  21   52    2  bonus\n"
+	if got := FormatLinescore(game); got != want {
+		t.Fatalf("FormatLinescore =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatGamesListDetail(t *testing.T) {
+	games := []nba.Game{
+		{ID: "1", GameStatus: 3, GameStatusText: "Final",
+			AwayTeam: nba.Team{Tricode: "NYK", Score: 105, Periods: periods(28, 25, 30, 22)},
+			HomeTeam: nba.Team{Tricode: "BOS", Score: 108, Periods: periods(31, 24, 27, 26)}},
+	}
+	if out := FormatGamesList(games); strings.Contains(out, "Q1") {
+		t.Fatalf("linescore shown without detail: %s", out)
+	}
+	out := FormatGamesListDetail(games, nil)
+	if !strings.Contains(out, "           Q1  Q2  Q3  Q4    T") || !strings.Contains(out, "    BOS    31  24  27  26  108") {
+		t.Fatalf("expected an indented linescore: %s", out)
+	}
+}
+
+func TestFormatGameSummary_Linescore(t *testing.T) {
+	summary := nba.NewGameSummary(nba.Game{
+		GameStatus: 3, GameStatusText: "Final",
+		AwayTeam: nba.Team{Tricode: "NYK", Score: 105, Periods: periods(28, 25, 30, 22)},
+		HomeTeam: nba.Team{Tricode: "BOS", Score: 108, Periods: periods(31, 24, 27, 26)},
+	})
+	if out := FormatGameSummary(summary); !strings.Contains(out, "NYK    28  25  30  22  105") {
+		t.Fatalf("expected the linescore in the summary: %s", out)
+	}
+}
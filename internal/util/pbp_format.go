@@ -0,0 +1,45 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+// FormatPlayByPlay renders actions one per line with period, clock, team and
+// the running score
+func FormatPlayByPlay(actions []nba.Action) string {
+	builder := strings.Builder{}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	faint := color.New(color.Faint).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+
+	builder.WriteString(fmt.Sprintf("\n📝 %s - %d action(s)\n", bold("Play-by-Play"), len(actions)))
+	builder.WriteString(strings.Repeat("─", 60) + "\n\n")
+
+	for _, a := range actions {
+		home, away := a.Score()
+		prefix := fmt.Sprintf("%-3s %s  %-3s %3d-%-3d", nba.PeriodLabel(a.Period), a.ClockDisplay(), a.TeamTricode, away, home)
+
+		line := a.Description
+		switch a.Kind() {
+		case nba.ActionShotMade:
+			line = green(line)
+		case nba.ActionFoul, nba.ActionTurnover:
+			line = yellow(line)
+		case nba.ActionSubstitution, nba.ActionTimeout:
+			line = faint(line)
+		case nba.ActionPeriodStart, nba.ActionPeriodEnd:
+			line = bold(line)
+		}
+
+		builder.WriteString(fmt.Sprintf("%s  %s\n", prefix, line))
+	}
+
+	builder.WriteString("\n")
+	return builder.String()
+}
@@ -0,0 +1,18 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestFormatPlayByPlay_ShowsClockAndScore(t *testing.T) {
+	actions := []nba.Action{
+		{Period: 5, Clock: "PT04M12.00S", TeamTricode: "BOS", ActionType: "3pt", ShotResult: "Made", ScoreHome: "110", ScoreAway: "107", Description: "Tatum 26' 3PT (31 PTS)"},
+	}
+	out := FormatPlayByPlay(actions)
+	if !strings.Contains(out, "OT  04:12") || !strings.Contains(out, "107-110") || !strings.Contains(out, "Tatum 26' 3PT") {
+		t.Fatalf("unexpected play-by-play output: %s", out)
+	}
+}
@@ -0,0 +1,80 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/internetdrew/bball/internal/standings"
+)
+
+const pictureRow = "%3s %1s %-4s %3s %3s  %5s %5s  %4s  %5s %5s"
+
+// FormatPlayoffPicture renders each conference's seeds, play-in and the
+// teams outside it. MAGIC is the magic number for the part of the picture a
+// team is in now; ELIM is the elimination number for the part above it
+func FormatPlayoffPicture(pictures []standings.Picture) string {
+	builder := strings.Builder{}
+	bold := color.New(color.Bold).SprintFunc()
+	faint := color.New(color.Faint).SprintFunc()
+
+	for _, p := range pictures {
+		builder.WriteString(fmt.Sprintf("\n%s\n", bold(p.Conference)))
+		builder.WriteString(faint(fmt.Sprintf(pictureRow, "#", "", "TEAM", "W", "L", "PCT", "GB", "LEFT", "MAGIC", "ELIM")) + "\n")
+
+		sections := []struct {
+			name    string
+			entries []standings.Entry
+		}{{"Playoffs", p.Playoffs}, {"Play-in", p.PlayIn}, {"Out", p.Out}}
+		for _, section := range sections {
+			if len(section.entries) == 0 {
+				continue
+			}
+			builder.WriteString(faint(section.name) + "\n")
+			for _, e := range section.entries {
+				magic, elim := raceNumbers(e)
+				builder.WriteString(fmt.Sprintf(pictureRow+"\n",
+					strconv.Itoa(e.Seed), e.Marker, e.Tricode, strconv.Itoa(e.Wins), strconv.Itoa(e.Losses),
+					FormatPct(e.Pct), FormatGamesBack(e.GamesBack), strconv.Itoa(e.GamesLeft), magic, elim,
+				))
+			}
+		}
+
+		if len(p.PlayIn) == 4 {
+			in := p.PlayIn
+			builder.WriteString(faint("Play-in tournament") + "\n")
+			builder.WriteString(fmt.Sprintf("  7 %s vs 8 %s — winner is the 7 seed\n", in[0].Tricode, in[1].Tricode))
+			builder.WriteString(fmt.Sprintf("  9 %s vs 10 %s — loser is out\n", in[2].Tricode, in[3].Tricode))
+			builder.WriteString("  Loser of 7/8 vs winner of 9/10 — winner is the 8 seed\n")
+		}
+	}
+
+	builder.WriteString("\n" + faint(fmt.Sprintf("%s clinched the conference · %s clinched the division · %s clinched a playoff spot · %s play-in at best · %s eliminated",
+		standings.ClinchedConference, standings.ClinchedDivision, standings.ClinchedPlayoffs, standings.PlayInAtBest, standings.Eliminated)) + "\n\n")
+	return builder.String()
+}
+
+// raceNumbers picks the magic and elimination numbers worth showing for e:
+// the top six for seeds 1-6, the top ten for the play-in, and the line
+// above for everyone chasing one. Settled races show "-".
+func raceNumbers(e standings.Entry) (magic, elim string) {
+	magic, elim = "-", "-"
+	switch {
+	case e.Seed <= standings.PlayoffSeeds:
+		magic = raceNumber(e.Playoffs.Magic)
+	case e.Seed <= standings.PlayoffField:
+		magic = raceNumber(e.PlayIn.Magic)
+		elim = raceNumber(e.Playoffs.Elimination)
+	default:
+		elim = raceNumber(e.PlayIn.Elimination)
+	}
+	return magic, elim
+}
+
+func raceNumber(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/standings"
+)
+
+func TestFormatPlayoffPicture(t *testing.T) {
+	entry := func(seed int, tricode, marker string) standings.Entry {
+		return standings.Entry{
+			Seed: seed, Tricode: tricode, Marker: marker, Wins: 50 - seed, Losses: 20 + seed, GamesLeft: 12,
+			Playoffs: standings.Race{Magic: seed, Elimination: 20 - seed},
+			PlayIn:   standings.Race{Magic: 0, Elimination: 30 - seed},
+		}
+	}
+	pictures := []standings.Picture{{
+		Conference: "East",
+		Playoffs:   []standings.Entry{entry(1, "BOS", standings.ClinchedConference)},
+		PlayIn:     []standings.Entry{entry(7, "MIA", ""), entry(8, "CHI", ""), entry(9, "ATL", ""), entry(10, "TOR", "")},
+		Out:        []standings.Entry{entry(11, "WAS", standings.Eliminated)},
+	}}
+
+	out := FormatPlayoffPicture(pictures)
+	for _, want := range []string{
+		"East", "Playoffs", "Play-in",
+		"  1 z BOS   49  21",
+		"  7   MIA   43  27   .000     -    12      -    13", // play-in clinched; 13 from the top six
+		" 11 e WAS   39  31   .000     -    12      -    19",
+		"7 MIA vs 8 CHI — winner is the 7 seed",
+		"9 ATL vs 10 TOR — loser is out",
+		"x clinched a playoff spot",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+// FormatPreview renders the lead-up to a game: tip time, records, where
+// it's played and on what channel, each team's rest and the last meeting
+func FormatPreview(p nba.Preview) string {
+	builder := strings.Builder{}
+	g := p.Game
+
+	builder.WriteString(fmt.Sprintf("🏀 %s (%s) at %s (%s)\n",
+		g.AwayTeam.Name, teamRecord(g.AwayTeam), g.HomeTeam.Name, teamRecord(g.HomeTeam)))
+	switch state := g.State(); {
+	case state.IsOff():
+		builder.WriteString(ColorStatus(g, fmt.Sprintf("⚠️ %s — %s", formatGameDayIn(g.StartTimeUTC(), gameLocation(g)), state)) + "\n")
+	case state.IsTimeTBD():
+		builder.WriteString(fmt.Sprintf("📅 %s, time TBD\n", formatGameDayIn(g.StartTimeUTC(), gameLocation(g))))
+	default:
+		builder.WriteString(fmt.Sprintf("📅 %s\n", formatGameDateIn(g.StartTimeUTC(), gameLocation(g))))
+	}
+	if series := g.SeriesLabel(); series != "" {
+		builder.WriteString(fmt.Sprintf("🏆 %s\n", series))
+	}
+	if arena := formatArena(g); arena != "" {
+		builder.WriteString(fmt.Sprintf("📍 %s\n", arena))
+	}
+	if tv := FormatBroadcasters(g.Broadcasters); tv != "" {
+		builder.WriteString(fmt.Sprintf("📺 %s\n", tv))
+	}
+
+	builder.WriteString("\n")
+	builder.WriteString(fmt.Sprintf("Rest:          %s %s · %s %s\n",
+		g.AwayTeam.Tricode, formatRest(p.AwayRest), g.HomeTeam.Tricode, formatRest(p.HomeRest)))
+	if m := p.LastMeeting; m != nil {
+		builder.WriteString(fmt.Sprintf("Last meeting:  %s — %s (%s won by %d)\n",
+			formatGameDayIn(m.Game.StartTimeUTC(), gameLocation(m.Game)), matchup(m.Game), m.Winner, abs(m.Margin)))
+	} else {
+		builder.WriteString("Last meeting:  none this season\n")
+	}
+	return builder.String()
+}
+
+// FormatOffDay renders a team's last final and next game, for a day it
+// doesn't play
+func FormatOffDay(o nba.OffDay) string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("No %s game today.\n\n", o.Team))
+
+	if g := o.Last; g != nil {
+		result := color.RedString("L")
+		if won(*g, o.Team) {
+			result = color.GreenString("W")
+		}
+		builder.WriteString(fmt.Sprintf("Last game:  %s — %s %s\n",
+			formatGameDayIn(g.StartTimeUTC(), gameLocation(*g)), matchup(*g), result))
+	} else {
+		builder.WriteString("Last game:  none yet this season\n")
+	}
+
+	if g := o.Next; g != nil {
+		line := fmt.Sprintf("%s — %s @ %s", formatGameDayIn(g.StartTimeUTC(), gameLocation(*g)), g.AwayTeam.Tricode, g.HomeTeam.Tricode)
+		switch state := g.State(); {
+		case state.IsOff():
+			line += ", " + strings.ToLower(state.String())
+		case state.IsTimeTBD():
+			line += ", time TBD"
+		default:
+			line += ", " + FormatTipTime(*g)
+		}
+		if tv := FormatBroadcasters(g.Broadcasters); tv != "" {
+			line += " · 📺 " + tv
+		}
+		builder.WriteString(fmt.Sprintf("Next game:  %s\n", line))
+	} else {
+		builder.WriteString("Next game:  none scheduled\n")
+	}
+	return builder.String()
+}
+
+// teamRecord returns a team's record like "12-5", or "0-0" before it has
+// played
+func teamRecord(t nba.Team) string {
+	return nba.Record{Wins: t.Wins, Losses: t.Losses}.String()
+}
+
+// formatArena returns e.g. "TD Garden, Boston, MA"
+func formatArena(g nba.Game) string {
+	var parts []string
+	for _, part := range []string{g.ArenaName, g.ArenaCity, g.ArenaState} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatRest describes days off like "2 days" or "back-to-back"
+func formatRest(days *int) string {
+	switch {
+	case days == nil:
+		return "first game"
+	case *days == 0:
+		return "back-to-back"
+	case *days == 1:
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", *days)
+}
+
+// matchup returns a final like "NYK 105 @ BOS 118"
+func matchup(g nba.Game) string {
+	return fmt.Sprintf("%s %d @ %s %d", g.AwayTeam.Tricode, g.AwayTeam.Score, g.HomeTeam.Tricode, g.HomeTeam.Score)
+}
+
+// won reports whether the team with tricode won the final g
+func won(g nba.Game, tricode string) bool {
+	if g.HomeTeam.Tricode == tricode {
+		return g.HomeTeam.Score > g.AwayTeam.Score
+	}
+	return g.AwayTeam.Score > g.HomeTeam.Score
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
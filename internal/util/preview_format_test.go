@@ -0,0 +1,68 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+func TestFormatPreview(t *testing.T) {
+	withTimezone(t, "America/New_York")
+
+	rest, backToBack := 3, 0
+	p := nba.Preview{
+		Game: nba.Game{GameStatus: 1, GameDateTimeUTC: "2024-10-26T23:30:00Z",
+			HomeTeam:  nba.Team{Tricode: "NYK", Name: "Knicks", Wins: 1, Losses: 1},
+			AwayTeam:  nba.Team{Tricode: "BOS", Name: "Celtics", Wins: 2},
+			ArenaName: "Madison Square Garden", ArenaCity: "New York", ArenaState: "NY",
+			Broadcasters: nba.Broadcasters{National: []nba.Broadcaster{{Display: "ESPN"}}}},
+		AwayRest: &backToBack, HomeRest: &rest,
+		LastMeeting: &nba.Meeting{
+			Game: nba.Game{GameStatus: 3, GameDateTimeUTC: "2024-10-22T23:30:00Z",
+				HomeTeam: nba.Team{Tricode: "BOS", Score: 132}, AwayTeam: nba.Team{Tricode: "NYK", Score: 109}},
+			Home: true, Winner: "BOS", Margin: 23,
+		},
+	}
+
+	out := FormatPreview(p)
+	for _, want := range []string{
+		"Celtics (2-0) at Knicks (1-1)",
+		"Saturday, October 26, 2024 at 7:30 PM EDT",
+		"📍 Madison Square Garden, New York, NY",
+		"📺 ESPN",
+		"Rest:          BOS back-to-back · NYK 3 days",
+		"Last meeting:  Tue Oct 22 — NYK 109 @ BOS 132 (BOS won by 23)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatOffDay(t *testing.T) {
+	withTimezone(t, "America/New_York")
+
+	o := nba.OffDay{
+		Team: "BOS",
+		Last: &nba.Game{GameStatus: 3, GameDateTimeUTC: "2024-10-22T23:30:00Z",
+			HomeTeam: nba.Team{Tricode: "BOS", Score: 132}, AwayTeam: nba.Team{Tricode: "NYK", Score: 109}},
+		Next: &nba.Game{GameStatus: 1, GameDateTimeUTC: "2024-10-26T23:30:00Z",
+			HomeTeam: nba.Team{Tricode: "NYK"}, AwayTeam: nba.Team{Tricode: "BOS"}},
+	}
+
+	out := FormatOffDay(o)
+	for _, want := range []string{
+		"No BOS game today.",
+		"Last game:  Tue Oct 22 — NYK 109 @ BOS 132",
+		"Next game:  Sat Oct 26 — BOS @ NYK, 7:30 PM EDT",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	if out := FormatOffDay(nba.OffDay{Team: "BOS"}); !strings.Contains(out, "none scheduled") {
+		t.Errorf("expected no next game in:\n%s", out)
+	}
+}
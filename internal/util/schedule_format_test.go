@@ -8,6 +8,8 @@ import (
 )
 
 func TestFormatTeamSchedule_IncludesDateForScheduled(t *testing.T) {
+	withTimezone(t, "America/New_York")
+
 	games := []nba.Game{
 		{
 			GameStatus:     1,
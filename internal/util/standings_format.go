@@ -0,0 +1,59 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/internetdrew/bball/internal/standings"
+)
+
+const standingsRow = "%3s  %-4s %3s %3s  %5s %5s  %6s %6s %6s %6s %6s %5s"
+
+// FormatStandings renders one table per group, followed by how each tie
+// was broken when explain is set
+func FormatStandings(groups []standings.Group, explain bool) string {
+	builder := strings.Builder{}
+	bold := color.New(color.Bold).SprintFunc()
+	faint := color.New(color.Faint).SprintFunc()
+
+	for _, g := range groups {
+		builder.WriteString(fmt.Sprintf("\n%s\n", bold(g.Name)))
+		builder.WriteString(faint(fmt.Sprintf(standingsRow, "#", "TEAM", "W", "L", "PCT", "GB", "HOME", "ROAD", "CONF", "DIV", "L10", "STRK")) + "\n")
+		for _, t := range g.Teams {
+			builder.WriteString(fmt.Sprintf(standingsRow+"\n",
+				fmt.Sprint(t.Rank), t.Tricode, fmt.Sprint(t.Wins), fmt.Sprint(t.Losses),
+				FormatPct(t.Pct), FormatGamesBack(t.GamesBack),
+				t.Home, t.Road, t.ConferenceRecord, t.DivisionRecord, t.LastTen, t.Streak,
+			))
+		}
+
+		if explain && len(g.Tiebreaks) > 0 {
+			builder.WriteString(faint("Tiebreakers") + "\n")
+			for _, tie := range g.Tiebreaks {
+				builder.WriteString(fmt.Sprintf("  %s (%s)\n", strings.Join(tie.Teams, " > "), tie.Record))
+				for _, step := range tie.Steps {
+					builder.WriteString(fmt.Sprintf("    %s\n", step))
+				}
+			}
+		}
+	}
+
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// FormatPct returns a winning percentage the way standings print it, like
+// ".683" or "1.000"
+func FormatPct(pct float64) string {
+	s := fmt.Sprintf("%.3f", pct)
+	return strings.TrimPrefix(s, "0")
+}
+
+// FormatGamesBack returns "-" for the leader and e.g. "3.5" otherwise
+func FormatGamesBack(gb float64) string {
+	if gb == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", gb)
+}
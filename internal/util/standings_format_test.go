@@ -0,0 +1,52 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/internetdrew/bball/internal/nba"
+	"github.com/internetdrew/bball/internal/standings"
+)
+
+func TestFormatStandings(t *testing.T) {
+	groups := []standings.Group{{Name: "East", Teams: []standings.Team{
+		{Tricode: "BOS", Rank: 1, Wins: 41, Losses: 19, Pct: 41.0 / 60, Home: nba.Record{Wins: 22, Losses: 8}, Streak: "W3"},
+		{Tricode: "NYK", Rank: 2, Wins: 38, Losses: 22, Pct: 38.0 / 60, GamesBack: 3, Streak: "L1"},
+	}}}
+
+	out := FormatStandings(groups, false)
+	for _, want := range []string{"East", "PCT", "BOS   41  19   .683     -    22-8", "NYK   38  22   .633   3.0", "W3", "L1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatStandings_Explain(t *testing.T) {
+	groups := []standings.Group{{
+		Name:  "East",
+		Teams: []standings.Team{{Tricode: "BOS", Rank: 1}, {Tricode: "NYK", Rank: 2}},
+		Tiebreaks: []standings.Tiebreak{{
+			Teams: []string{"BOS", "NYK"}, Record: "2-1",
+			Steps: []standings.Step{{Rule: standings.HeadToHead, Teams: []string{"BOS", "NYK"}, Values: []string{"1-0", "0-1"}}},
+		}},
+	}}
+
+	if out := FormatStandings(groups, false); strings.Contains(out, "Tiebreakers") {
+		t.Fatalf("tiebreakers shown without explain:\n%s", out)
+	}
+	out := FormatStandings(groups, true)
+	for _, want := range []string{"BOS > NYK (2-1)", "head-to-head: BOS 1-0, NYK 0-1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatPct(t *testing.T) {
+	for pct, want := range map[float64]string{0: ".000", 0.5: ".500", 1: "1.000", 2.0 / 3: ".667"} {
+		if got := FormatPct(pct); got != want {
+			t.Errorf("FormatPct(%v) = %q, want %q", pct, got, want)
+		}
+	}
+}
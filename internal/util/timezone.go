@@ -0,0 +1,79 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+// Location is the zone dates and tip-off times are shown in. It defaults to
+// the system's local zone.
+var Location = time.Local
+
+// ArenaTime shows each game in its arena's local zone instead of Location.
+var ArenaTime bool
+
+// SetTimezone configures how times are displayed from a --tz value: "local",
+// "arena", or an IANA zone name such as "America/Los_Angeles".
+func SetTimezone(name string) error {
+	name = strings.TrimSpace(name)
+	switch strings.ToLower(name) {
+	case "", "local":
+		Location, ArenaTime = time.Local, false
+		return nil
+	case "arena":
+		Location, ArenaTime = time.Local, true
+		return nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("unknown time zone %q (use local, arena, or a name like America/Chicago)", name)
+	}
+	Location, ArenaTime = loc, false
+	return nil
+}
+
+// gameLocation returns the zone a game's times are shown in.
+func gameLocation(game nba.Game) *time.Location {
+	if ArenaTime {
+		if loc := game.ArenaLocation(); loc != nil {
+			return loc
+		}
+	}
+	return Location
+}
+
+// FormatTipTime returns the tip-off time like "7:30 PM PDT", falling back to
+// the feed's status text when the start time is unknown
+func FormatTipTime(game nba.Game) string {
+	t, err := time.Parse(time.RFC3339, game.StartTimeUTC())
+	if err != nil {
+		return game.GameStatusText
+	}
+	return t.In(gameLocation(game)).Format("3:04 PM MST")
+}
+
+// ParseDay interprets a day relative to now: YYYY-MM-DD, "today",
+// "yesterday" or "tomorrow", with empty meaning today. The day starts at
+// midnight in now's location.
+func ParseDay(value string, now time.Time) (day time.Time, isToday bool, err error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "today":
+		return today, true, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), false, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), false, nil
+	}
+
+	day, err = time.ParseInLocation("2006-01-02", strings.TrimSpace(value), now.Location())
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid day %q", value)
+	}
+	return day, day.Equal(today), nil
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/internetdrew/bball/internal/nba"
+)
+
+// withTimezone sets the display zone for the duration of a test.
+func withTimezone(t *testing.T, name string) {
+	t.Helper()
+	oldLoc, oldArena := Location, ArenaTime
+	t.Cleanup(func() { Location, ArenaTime = oldLoc, oldArena })
+	if err := SetTimezone(name); err != nil {
+		t.Skipf("time zone %s not available: %v", name, err)
+	}
+}
+
+func TestSetTimezone(t *testing.T) {
+	withTimezone(t, "local")
+	if Location != time.Local || ArenaTime {
+		t.Fatalf("local should use the system zone")
+	}
+
+	if err := SetTimezone("arena"); err != nil || !ArenaTime {
+		t.Fatalf("arena should enable arena time, got %v", err)
+	}
+
+	if err := SetTimezone("Mars/Olympus_Mons"); err == nil {
+		t.Fatalf("expected error for unknown zone")
+	}
+}
+
+func TestFormatGameDate_HonorsLocation(t *testing.T) {
+	withTimezone(t, "America/Los_Angeles")
+	out := FormatGameDate("2023-11-04T23:30:00Z")
+	if !strings.Contains(out, "4:30 PM PDT") {
+		t.Fatalf("expected Pacific time, got %s", out)
+	}
+}
+
+func TestFormatGamesList_ArenaTime(t *testing.T) {
+	withTimezone(t, "arena")
+	games := []nba.Game{{
+		GameStatus: 1, GameStatusText: "7:30 pm ET", GameDateTimeUTC: "2024-01-10T03:30:00Z",
+		HomeTeam: nba.Team{Tricode: "LAL"}, AwayTeam: nba.Team{Tricode: "BOS"},
+	}}
+	out := FormatGamesList(games)
+	if !strings.Contains(out, "7:30 PM PST") {
+		t.Fatalf("expected tip time in arena zone, got %s", out)
+	}
+}
@@ -1,6 +1,11 @@
 package main
 
-import "github.com/internetdrew/bball/cmd"
+import (
+	// Embed the zone database so --tz works on systems without one.
+	_ "time/tzdata"
+
+	"github.com/internetdrew/bball/cmd"
+)
 
 func main() {
 	cmd.Execute()